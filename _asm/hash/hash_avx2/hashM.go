@@ -0,0 +1,184 @@
+package main
+
+import (
+	. "github.com/mmcloughlin/avo/build"
+	. "github.com/mmcloughlin/avo/operand"
+	. "github.com/mmcloughlin/avo/reg"
+	. "github.com/zeebo/blake3/_asm"
+)
+
+// HashM compresses up to 16 blocks of 8 independent messages, one message
+// per 1024 byte lane of the input. Every lane has its own key, and its own
+// block length and flags for each block. The chain of a lane is stored into
+// out after every block with the chunk end or parent flag.
+func HashM(c Ctx) {
+	TEXT("HashM", 0, `func(
+		input *[8192]byte,
+		blocks uint64,
+		counter uint64,
+		key *[64]uint32,
+		blen *[16][8]uint32,
+		flags *[16][8]uint32,
+		out *[64]uint32,
+	)`)
+
+	var (
+		input   = Mem{Base: Load(Param("input"), GP64())}
+		blocks  = Load(Param("blocks"), GP64()).(GPVirtual)
+		counter = Load(Param("counter"), GP64()).(GPVirtual)
+		key     = Mem{Base: Load(Param("key"), GP64())}
+		blen    = Mem{Base: Load(Param("blen"), GP64())}
+		flags   = Mem{Base: Load(Param("flags"), GP64())}
+		out     = Mem{Base: Load(Param("out"), GP64())}
+	)
+
+	loop := GP64()
+	row := GP64()
+	stash := GP64()
+
+	// All of the locals share one 32-byte aligned arena, so no vector slot straddles a cache line.
+	const (
+		arenaMsg     = 0
+		arenaSpills  = arenaMsg + 16*32
+		arenaCounter = arenaSpills + roundSize
+		arenaSize    = arenaCounter + 8
+	)
+
+	{
+		Comment("Allocate local space and align it")
+		local := AllocLocal(arenaSize + 32)
+		LEAQ(local.Offset(31), stash)
+		ANDQ(I32(^31), stash)
+	}
+
+	var (
+		msg         = Mem{Base: stash}.Offset(arenaMsg)
+		counter_mem = Mem{Base: stash}.Offset(arenaCounter)
+	)
+
+	alloc := NewAlloc(Mem{Base: stash}.Offset(arenaSpills))
+	defer alloc.Free()
+
+	var (
+		h_vecs    []*Value
+		h_regs    []int
+		vs        []*Value
+		iv        []*Value
+		ctr_low   *Value
+		ctr_hi    *Value
+		blen_vec  *Value
+		flags_vec *Value
+	)
+
+	h_vecs = alloc.ValuesFrom(8, key)
+	iv = alloc.ValuesWith(4, c.IV)
+
+	{
+		Comment("Load some params into the stack (avo improvment?)")
+		MOVQ(counter, counter_mem)
+	}
+
+	{
+		Comment("Load keys into vectors")
+		h_regs = make([]int, 8)
+		for i, v := range h_vecs {
+			h_regs[i] = v.Reg()
+			_ = v.Get()
+		}
+	}
+
+	{
+		Comment("Convert the number of blocks into the end of the loop")
+		SHLQ(U8(6), blocks)
+		XORQ(loop, loop)
+		XORQ(row, row)
+	}
+
+	Label("loop")
+
+	{
+		Comment("Load and transpose message vectors")
+		transposeMsg(c, alloc, loop, input, msg)
+	}
+
+	{
+		Comment("Load constants for the round")
+		blen_vec = alloc.ValueFrom(blen.Idx(row, 1))
+		flags_vec = alloc.ValueFrom(flags.Idx(row, 1))
+		ctr_low = alloc.ValueWith(counter_mem)
+		ctr_hi = alloc.ValueWith(counter_mem.Offset(4))
+
+		for _, v := range h_vecs {
+			_ = v.Get()
+		}
+		_ = blen_vec.Get()
+		_ = flags_vec.Get()
+		for _, v := range iv {
+			_ = v.Get()
+		}
+		_ = ctr_low.Get()
+		_ = ctr_hi.Get()
+	}
+
+	{
+		Comment("Perform the rounds")
+
+		vs = []*Value{
+			h_vecs[0], h_vecs[1], h_vecs[2], h_vecs[3],
+			h_vecs[4], h_vecs[5], h_vecs[6], h_vecs[7],
+			iv[0], iv[1], iv[2], iv[3],
+			ctr_low, ctr_hi, blen_vec, flags_vec,
+		}
+
+		for r := 0; r < 7; r++ {
+			Commentf("Round %d", r+1)
+			roundF(c, alloc, vs, r, msg)
+		}
+	}
+
+	{
+		Comment("Finalize rounds")
+		finalizeRounds(alloc, vs, h_vecs, h_regs)
+	}
+
+	{
+		Comment("Fix up registers for next iteration")
+		for i := 7; i >= 0; i-- {
+			h_vecs[i].Become(h_regs[i])
+		}
+	}
+
+	{
+		Comment("Store the chains of the lanes that ended a chunk or parent")
+		mask, tmp := alloc.Value(), alloc.Value()
+		VMOVDQU(flags.Idx(row, 1), mask.Get())
+		VPSLLD(U8(29), mask.Get(), tmp.Get())
+		VPSLLD(U8(30), mask.Get(), mask.Get())
+		VPOR(tmp.ConsumeOp(), mask.Get(), mask.Get())
+		for i, v := range h_vecs {
+			VPMASKMOVD(v.Get(), mask.Get(), out.Offset(32*i))
+		}
+		mask.Free()
+	}
+
+	{
+		Comment("Increment and loop")
+		ADDQ(Imm(64), loop)
+		ADDQ(Imm(32), row)
+		CMPQ(loop, blocks)
+		JNE(LabelRef("loop"))
+	}
+
+	{
+		Comment("Release the chains")
+		for _, v := range h_vecs {
+			v.Free()
+		}
+		for _, v := range iv {
+			v.Free()
+		}
+	}
+
+	VZEROUPPER()
+	RET()
+}
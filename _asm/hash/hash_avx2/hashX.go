@@ -0,0 +1,134 @@
+package main
+
+import (
+	. "github.com/mmcloughlin/avo/build"
+	. "github.com/mmcloughlin/avo/operand"
+	. "github.com/mmcloughlin/avo/reg"
+	. "github.com/zeebo/blake3/_asm"
+)
+
+// HashX computes 16 consecutive 64 byte blocks of output of the root node,
+// eight blocks at a time, one per lane.
+func HashX(c Ctx) {
+	TEXT("HashX", 0, `func(
+		chain *[8]uint32,
+		block *[16]uint32,
+		counter uint64,
+		blen uint32,
+		flags uint32,
+		out *[1024]byte,
+	)`)
+
+	var (
+		chain   = Mem{Base: Load(Param("chain"), GP64())}
+		block   = Mem{Base: Load(Param("block"), GP64())}
+		counter = Load(Param("counter"), GP64()).(GPVirtual)
+		blen    = Load(Param("blen"), GP32()).(GPVirtual)
+		flags   = Load(Param("flags"), GP32()).(GPVirtual)
+		out     = Mem{Base: Load(Param("out"), GP64())}
+	)
+
+	stash := GP64()
+
+	// All of the locals share one 32-byte aligned arena, so no vector slot straddles a cache line.
+	const (
+		arenaMsg     = 0
+		arenaSpills  = arenaMsg + 16*32
+		arenaCtrLo   = arenaSpills + roundSize
+		arenaCtrHi   = arenaCtrLo + 32
+		arenaCounter = arenaCtrHi + 32
+		arenaBlen    = arenaCounter + 8
+		arenaFlags   = arenaBlen + 4
+		arenaSize    = arenaFlags + 4
+	)
+
+	{
+		Comment("Allocate local space and align it")
+		local := AllocLocal(arenaSize + 32)
+		LEAQ(local.Offset(31), stash)
+		ANDQ(I32(^31), stash)
+	}
+
+	var (
+		msg         = Mem{Base: stash}.Offset(arenaMsg)
+		ctr_lo_mem  = Mem{Base: stash}.Offset(arenaCtrLo)
+		ctr_hi_mem  = Mem{Base: stash}.Offset(arenaCtrHi)
+		counter_mem = Mem{Base: stash}.Offset(arenaCounter)
+		blen_mem    = Mem{Base: stash}.Offset(arenaBlen)
+		flags_mem   = Mem{Base: stash}.Offset(arenaFlags)
+	)
+
+	alloc := NewAlloc(Mem{Base: stash}.Offset(arenaSpills))
+	defer alloc.Free()
+
+	{
+		Comment("Load some params into the stack (avo improvment?)")
+		MOVL(blen, blen_mem)
+		MOVL(flags, flags_mem)
+	}
+
+	{
+		Comment("Broadcast the message words, which are the same for every block")
+		for i := 0; i < 16; i++ {
+			v := alloc.ValueWith(block.Offset(4 * i))
+			VMOVDQA(v.Consume(), msg.Offset(32*i))
+		}
+	}
+
+	for half := 0; half < 2; half++ {
+		Commentf("Compute blocks %d through %d", 8*half, 8*half+7)
+
+		{
+			Comment("Build and store counter data on the stack")
+			MOVQ(counter, counter_mem)
+			loadCounter(c, alloc, counter_mem, ctr_lo_mem, ctr_hi_mem)
+			ADDQ(Imm(8), counter)
+		}
+
+		h_vecs := alloc.ValuesWith(8, chain)
+		iv := alloc.ValuesWith(4, c.IV)
+		vs := []*Value{
+			h_vecs[0], h_vecs[1], h_vecs[2], h_vecs[3],
+			h_vecs[4], h_vecs[5], h_vecs[6], h_vecs[7],
+			iv[0], iv[1], iv[2], iv[3],
+			alloc.ValueFrom(ctr_lo_mem), alloc.ValueFrom(ctr_hi_mem),
+			alloc.ValueWith(blen_mem), alloc.ValueWith(flags_mem),
+		}
+
+		{
+			Comment("Perform the rounds")
+			for r := 0; r < 7; r++ {
+				Commentf("Round %d", r+1)
+				roundF(c, alloc, vs, r, msg)
+			}
+		}
+
+		lo, hi := make([]*Value, 8), make([]*Value, 8)
+
+		{
+			Comment("Finalize the output words")
+			for i := 0; i < 8; i++ {
+				cv := alloc.ValueWith(chain.Offset(4 * i))
+				hi[i] = alloc.Value()
+				VPXOR(vs[8+i].GetOp(), cv.Get(), hi[i].Get())
+				cv.Free()
+				lo[i] = xorb(alloc, vs[i], vs[8+i])
+			}
+		}
+
+		{
+			Comment("Transpose the words into blocks and store them")
+			transpose(c, alloc, lo)
+			for l, v := range lo {
+				VMOVDQU(v.Consume(), out.Offset(512*half+64*l))
+			}
+			transpose(c, alloc, hi)
+			for l, v := range hi {
+				VMOVDQU(v.Consume(), out.Offset(512*half+64*l+32))
+			}
+		}
+	}
+
+	VZEROUPPER()
+	RET()
+}
@@ -9,6 +9,8 @@ func main() {
 
 	HashF(c)
 	HashP(c)
+	HashM(c)
+	HashX(c)
 
 	build.Generate()
 }
@@ -10,6 +10,7 @@ type Ctx struct {
 	BlockLen  Mem
 	Zero      Mem
 	Transpose Mem
+	Counter   Mem
 }
 
 func NewCtx() (c Ctx) {
@@ -45,5 +46,10 @@ func NewCtx() (c Ctx) {
 		}
 	}
 
+	c.Counter = GLOBL("counter", RODATA|NOPTR)
+	for i := 0; i < 16; i++ {
+		DATA(8*i, U64(i))
+	}
+
 	return c
 }
@@ -0,0 +1,138 @@
+package main
+
+import (
+	. "github.com/mmcloughlin/avo/build"
+	. "github.com/mmcloughlin/avo/operand"
+	. "github.com/mmcloughlin/avo/reg"
+	. "github.com/zeebo/blake3/_asm"
+)
+
+// gX is the G function on four state rows that each hold one word of all 16
+// blocks. The message words are the same for every block, so they are
+// broadcast straight from memory.
+func gX(a, b, c, d VecPhysical, mx, my Mem) {
+	VPADDD(b, a, a)
+	VPADDD_BCST(mx, a, a)
+	VPXORD(a, d, d)
+	VPRORD(U8(16), d, d)
+	VPADDD(d, c, c)
+	VPXORD(c, b, b)
+	VPRORD(U8(12), b, b)
+	VPADDD(b, a, a)
+	VPADDD_BCST(my, a, a)
+	VPXORD(a, d, d)
+	VPRORD(U8(8), d, d)
+	VPADDD(d, c, c)
+	VPXORD(c, b, b)
+	VPRORD(U8(7), b, b)
+}
+
+// HashX computes 16 consecutive 64 byte blocks of output of the root node,
+// one per 32-bit lane of every register.
+func HashX(c Ctx) {
+	TEXT("HashX", NOSPLIT, `func(
+		chain *[8]uint32,
+		block *[16]uint32,
+		counter uint64,
+		blen uint32,
+		flags uint32,
+		out *[1024]byte,
+	)`)
+
+	var (
+		chain   = Mem{Base: Load(Param("chain"), GP64())}
+		block   = Mem{Base: Load(Param("block"), GP64())}
+		counter = Load(Param("counter"), GP64())
+		blen    = Load(Param("blen"), GP32())
+		flags   = Load(Param("flags"), GP32())
+		out     = Mem{Base: Load(Param("out"), GP64())}
+	)
+
+	v := ZmmRegs[0:16]
+	t := ZmmRegs[16:32]
+
+	{
+		Comment("Load the chaining value, IV, block length and flags into the state")
+		for i := 0; i < 8; i++ {
+			VPBROADCASTD(chain.Offset(4*i), v[i])
+		}
+		for i := 0; i < 4; i++ {
+			VPBROADCASTD(c.IV.Offset(4*i), v[8+i])
+		}
+		VPBROADCASTD(blen, v[14])
+		VPBROADCASTD(flags, v[15])
+	}
+
+	{
+		Comment("Split the counter of every block into its low and high words")
+		VPBROADCASTQ(counter, t[0])
+		VPADDQ(c.Counter, t[0], t[1])
+		VPADDQ(c.Counter.Offset(64), t[0], t[2])
+		VPMOVQD(t[1], v[12].AsY())
+		VPMOVQD(t[2], t[3].AsY())
+		VINSERTI64X4(Imm(1), t[3].AsY(), v[12], v[12])
+		VPSRLQ(U8(32), t[1], t[1])
+		VPSRLQ(U8(32), t[2], t[2])
+		VPMOVQD(t[1], v[13].AsY())
+		VPMOVQD(t[2], t[3].AsY())
+		VINSERTI64X4(Imm(1), t[3].AsY(), v[13], v[13])
+	}
+
+	for r := 0; r < 7; r++ {
+		Commentf("Round %d", r+1)
+		m := func(n int) Mem { return block.Offset(4 * msgSched[r][n]) }
+		gX(v[0], v[4], v[8], v[12], m(0), m(1))
+		gX(v[1], v[5], v[9], v[13], m(2), m(3))
+		gX(v[2], v[6], v[10], v[14], m(4), m(5))
+		gX(v[3], v[7], v[11], v[15], m(6), m(7))
+		gX(v[0], v[5], v[10], v[15], m(8), m(9))
+		gX(v[1], v[6], v[11], v[12], m(10), m(11))
+		gX(v[2], v[7], v[8], v[13], m(12), m(13))
+		gX(v[3], v[4], v[9], v[14], m(14), m(15))
+	}
+
+	{
+		Comment("Finalize the output words")
+		for i := 0; i < 8; i++ {
+			VPXORD(v[8+i], v[i], v[i])
+			VPXORD_BCST(chain.Offset(4*i), v[8+i], v[8+i])
+		}
+	}
+
+	{
+		Comment("Transpose the words into blocks")
+
+		// interleave pairs of words, then pairs of pairs, so that every 128
+		// bit lane of u[4g+j] holds words 4g through 4g+3 of one block.
+		for i := 0; i < 16; i += 2 {
+			VPUNPCKLDQ(v[i+1], v[i], t[i])
+			VPUNPCKHDQ(v[i+1], v[i], t[i+1])
+		}
+		u := v
+		for g := 0; g < 16; g += 4 {
+			VPUNPCKLQDQ(t[g+2], t[g], u[g])
+			VPUNPCKHQDQ(t[g+2], t[g], u[g+1])
+			VPUNPCKLQDQ(t[g+3], t[g+1], u[g+2])
+			VPUNPCKHQDQ(t[g+3], t[g+1], u[g+3])
+		}
+
+		// gather the 128 bit lanes of each block from the four groups and
+		// store them.
+		for j := 0; j < 4; j++ {
+			VSHUFI32X4(U8(0x44), u[4+j], u[j], t[0])
+			VSHUFI32X4(U8(0xee), u[4+j], u[j], t[1])
+			VSHUFI32X4(U8(0x44), u[12+j], u[8+j], t[2])
+			VSHUFI32X4(U8(0xee), u[12+j], u[8+j], t[3])
+			VSHUFI32X4(U8(0x88), t[2], t[0], t[4])
+			VSHUFI32X4(U8(0xdd), t[2], t[0], t[5])
+			VSHUFI32X4(U8(0x88), t[3], t[1], t[6])
+			VSHUFI32X4(U8(0xdd), t[3], t[1], t[7])
+			for k := 0; k < 4; k++ {
+				VMOVDQU32(t[4+k], out.Offset(64*(4*k+j)))
+			}
+		}
+	}
+
+	VZEROUPPER()
+	RET()
+}
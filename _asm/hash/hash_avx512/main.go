@@ -9,6 +9,7 @@ func main() {
 
 	HashF(c)
 	HashP(c)
+	HashX(c)
 
 	build.Generate()
 }
@@ -0,0 +1,167 @@
+// Package aead implements authenticated encryption with associated data using
+// only BLAKE3, for platforms where AES is slow or unavailable.
+//
+// For each message, the key and nonce are hashed in the derive key mode to
+// produce an output stream. Its first 32 bytes are the tag key and the stream
+// from byte 64 onward is XORed with the plaintext. The tag is the keyed
+// BLAKE3 hash, under the tag key, of the associated data, the ciphertext, and
+// the lengths of both as 64 bit little endian integers.
+//
+// The nonce is 24 bytes, long enough to be chosen at random for every
+// message. A nonce must never be used twice with the same key.
+package aead
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/zeebo/blake3"
+)
+
+const (
+	// KeySize is the size of the key used by this AEAD, in bytes.
+	KeySize = 32
+
+	// NonceSize is the size of the nonce used by this AEAD, in bytes.
+	NonceSize = 24
+
+	// Overhead is the size of the tag that is appended to the ciphertext.
+	Overhead = 32
+
+	// MaxMessageSize is the length of the longest plaintext that can be
+	// sealed, the same limit that ChaCha20-Poly1305 has. Longer messages
+	// should be split and sealed separately.
+	MaxMessageSize = 1 << 38
+
+	// streamOffset is where the keystream begins in the derived output,
+	// after the tag key and on a block boundary.
+	streamOffset = 64
+
+	// keyContext is the derive key context for the per message keys.
+	keyContext = "github.com/zeebo/blake3/aead 2026-10-18 18:24:10 stream and tag keys v1"
+)
+
+var errOpen = errors.New("message authentication failed")
+
+type aead struct {
+	key [KeySize]byte
+}
+
+// New returns a cipher.AEAD that uses the 32 byte key. The returned AEAD is
+// safe for concurrent use.
+func New(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, errors.New("invalid key size")
+	}
+	a := new(aead)
+	copy(a.key[:], key)
+	return a, nil
+}
+
+func (a *aead) NonceSize() int { return NonceSize }
+
+func (a *aead) Overhead() int { return Overhead }
+
+func (a *aead) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != NonceSize {
+		panic("aead: incorrect nonce length given to Seal")
+	}
+	if uint64(len(plaintext)) > MaxMessageSize {
+		panic("aead: plaintext too large")
+	}
+
+	ret, out := sliceForAppend(dst, len(plaintext)+Overhead)
+	d, tagKey := a.derive(nonce)
+
+	ciphertext := out[:len(plaintext)]
+	xorStream(d, ciphertext, plaintext)
+	tag(tagKey, out[len(plaintext):], additionalData, ciphertext)
+
+	return ret
+}
+
+func (a *aead) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != NonceSize {
+		panic("aead: incorrect nonce length given to Open")
+	}
+	if len(ciphertext) < Overhead || uint64(len(ciphertext)-Overhead) > MaxMessageSize {
+		return nil, errOpen
+	}
+
+	ciphertext, expected := ciphertext[:len(ciphertext)-Overhead], ciphertext[len(ciphertext)-Overhead:]
+	d, tagKey := a.derive(nonce)
+
+	var got [Overhead]byte
+	tag(tagKey, got[:], additionalData, ciphertext)
+	if subtle.ConstantTimeCompare(got[:], expected) != 1 {
+		return nil, errOpen
+	}
+
+	ret, out := sliceForAppend(dst, len(ciphertext))
+	xorStream(d, out, ciphertext)
+	return ret, nil
+}
+
+// derive returns the keystream, positioned at its start, and the tag key for
+// the nonce.
+func (a *aead) derive(nonce []byte) (*blake3.Digest, *[32]byte) {
+	var material [KeySize + NonceSize]byte
+	copy(material[:], a.key[:])
+	copy(material[KeySize:], nonce)
+
+	h := blake3.NewCompactDeriveKey(keyContext)
+	_, _ = h.Write(material[:])
+	d := h.Digest()
+
+	tagKey := new([32]byte)
+	_, _ = d.Read(tagKey[:])
+	_, _ = d.Seek(streamOffset, io.SeekStart)
+
+	return d, tagKey
+}
+
+// xorStream sets dst to src XORed with the next len(src) bytes of d. dst and
+// src may overlap exactly.
+func xorStream(d *blake3.Digest, dst, src []byte) {
+	var buf [4096]byte
+	for len(src) > 0 {
+		n := len(src)
+		if n > len(buf) {
+			n = len(buf)
+		}
+		_, _ = d.Read(buf[:n])
+		subtle.XORBytes(dst[:n], src[:n], buf[:n])
+		dst, src = dst[n:], src[n:]
+	}
+}
+
+// tag computes the tag of the associated data and ciphertext into out.
+func tag(key *[32]byte, out, additionalData, ciphertext []byte) {
+	h, _ := blake3.NewCompactKeyed(key[:])
+	_, _ = h.Write(additionalData)
+	_, _ = h.Write(ciphertext)
+
+	var lengths [16]byte
+	binary.LittleEndian.PutUint64(lengths[0:8], uint64(len(additionalData)))
+	binary.LittleEndian.PutUint64(lengths[8:16], uint64(len(ciphertext)))
+	_, _ = h.Write(lengths[:])
+
+	_, _ = h.Digest().Read(out)
+}
+
+// sliceForAppend takes a slice and a requested number of bytes. It returns a
+// slice with the contents of the given slice followed by that many bytes and
+// a second slice that aliases into it and contains only the extra bytes.
+func sliceForAppend(in []byte, n int) (head, tail []byte) {
+	if total := len(in) + n; cap(in) >= total {
+		head = in[:total]
+	} else {
+		head = make([]byte, total)
+		copy(head, in)
+	}
+	tail = head[len(in):]
+	return
+}
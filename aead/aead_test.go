@@ -0,0 +1,202 @@
+package aead
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/zeebo/assert"
+
+	"github.com/zeebo/blake3"
+)
+
+func testKeyNonce() (key, nonce []byte) {
+	key, nonce = make([]byte, KeySize), make([]byte, NonceSize)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	for i := range nonce {
+		nonce[i] = byte(0x40 + i)
+	}
+	return key, nonce
+}
+
+func testInput(n int) []byte {
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = uint8(i % 251)
+	}
+	return out
+}
+
+func TestVectors(t *testing.T) {
+	key, nonce := testKeyNonce()
+	a, err := New(key)
+	assert.NoError(t, err)
+
+	for _, test := range []struct {
+		plaintext string
+		ad        string
+		sealed    string
+	}{
+		{"", "", "c8159eb6642ff4d46f996a00697b20d55da5a4ec4eafa95347e5e5ad6c5a20ec"},
+		{"", "header", "c288cd1daad422fcef990a3fedadb36bf4989743b6e17463884da30513df6920"},
+		{"some data", "", "9e7b5f0d49bfbeaa365f691e194a6ab465a3c44d12b538f6a08e92ca1ed2693d8751d351b0d5d4e429"},
+		{"some data", "header", "9e7b5f0d49bfbeaa367c5fb1db7a9dc61bb6cdc8af6dee8d576c3a825b5ff0223c146a96ee2c8f0cc0"},
+	} {
+		sealed := a.Seal(nil, nonce, []byte(test.plaintext), []byte(test.ad))
+		assert.Equal(t, hex.EncodeToString(sealed), test.sealed)
+
+		opened, err := a.Open(nil, nonce, sealed, []byte(test.ad))
+		assert.NoError(t, err)
+		assert.Equal(t, string(opened), test.plaintext)
+	}
+
+	// longer inputs are checked by the hash of the sealed output
+	sealed := a.Seal(nil, nonce, testInput(2500), []byte("header"))
+	assert.Equal(t, len(sealed), 2500+Overhead)
+	assert.Equal(t, hex.EncodeToString(sealed[:16]), "ed15306b6dded9d95fa234dcb70a7905")
+	sum := blake3.Sum256(sealed)
+	assert.Equal(t, hex.EncodeToString(sum[:]), "f0e0021e4a39ce5307b8adb1e576557fe65ea295cd760838ce6deae0a65d8b39")
+}
+
+// seal is a straightforward implementation of the construction described in
+// the package documentation, using only the blake3 package.
+func seal(key, nonce, plaintext, ad []byte) []byte {
+	stream := make([]byte, streamOffset+len(plaintext))
+	blake3.DeriveKey(keyContext, append(append([]byte(nil), key...), nonce...), stream)
+
+	out := make([]byte, len(plaintext))
+	for i := range plaintext {
+		out[i] = plaintext[i] ^ stream[streamOffset+i]
+	}
+
+	h, _ := blake3.NewKeyed(stream[:32])
+	h.Write(ad)
+	h.Write(out)
+	h.Write(binary.LittleEndian.AppendUint64(nil, uint64(len(ad))))
+	h.Write(binary.LittleEndian.AppendUint64(nil, uint64(len(out))))
+	return h.Sum(out)
+}
+
+func TestConstruction(t *testing.T) {
+	key, nonce := testKeyNonce()
+	a, err := New(key)
+	assert.NoError(t, err)
+
+	for _, n := range []int{0, 1, 63, 64, 65, 1024, 4095, 4096, 4097, 8192, 10000, 100000} {
+		plaintext, ad := testInput(n), testInput(n/3)
+		assert.DeepEqual(t, a.Seal(nil, nonce, plaintext, ad), seal(key, nonce, plaintext, ad))
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	key, nonce := testKeyNonce()
+	a, err := New(key)
+	assert.NoError(t, err)
+
+	var _ cipher.AEAD = a
+	assert.Equal(t, a.NonceSize(), NonceSize)
+	assert.Equal(t, a.Overhead(), Overhead)
+
+	for _, n := range []int{0, 1, 100, 5000} {
+		plaintext, ad := testInput(n), []byte("header")
+
+		// sealing appends to dst
+		sealed := a.Seal([]byte("prefix"), nonce, plaintext, ad)
+		assert.Equal(t, string(sealed[:6]), "prefix")
+		sealed = sealed[6:]
+
+		opened, err := a.Open([]byte("prefix"), nonce, sealed, ad)
+		assert.NoError(t, err)
+		assert.Equal(t, string(opened[:6]), "prefix")
+		assert.DeepEqual(t, opened[6:], plaintext)
+
+		// sealing and opening in place
+		buf := append(make([]byte, 0, n+Overhead), plaintext...)
+		inPlace := a.Seal(buf[:0], nonce, buf, ad)
+		assert.DeepEqual(t, inPlace, sealed)
+		opened, err = a.Open(inPlace[:0], nonce, inPlace, ad)
+		assert.NoError(t, err)
+		assert.DeepEqual(t, opened, plaintext)
+	}
+}
+
+func TestOpen_Tampered(t *testing.T) {
+	key, nonce := testKeyNonce()
+	a, err := New(key)
+	assert.NoError(t, err)
+
+	plaintext, ad := []byte("some data"), []byte("header")
+	sealed := a.Seal(nil, nonce, plaintext, ad)
+
+	for i := range sealed {
+		bad := append([]byte(nil), sealed...)
+		bad[i] ^= 1
+		_, err := a.Open(nil, nonce, bad, ad)
+		assert.Error(t, err)
+	}
+
+	_, err = a.Open(nil, nonce, sealed, []byte("headers"))
+	assert.Error(t, err)
+	_, err = a.Open(nil, nonce, sealed, nil)
+	assert.Error(t, err)
+	_, err = a.Open(nil, nonce, sealed[:len(sealed)-1], ad)
+	assert.Error(t, err)
+	_, err = a.Open(nil, nonce, sealed[:Overhead-1], ad)
+	assert.Error(t, err)
+
+	// moving bytes between the associated data and the ciphertext must fail
+	moved := a.Seal(nil, nonce, append([]byte("r"), plaintext...), ad[:len(ad)-1])
+	_, err = a.Open(nil, nonce, moved, ad)
+	assert.Error(t, err)
+
+	otherNonce := append([]byte(nil), nonce...)
+	otherNonce[0] ^= 1
+	_, err = a.Open(nil, otherNonce, sealed, ad)
+	assert.Error(t, err)
+
+	otherKey := bytes.Repeat([]byte{1}, KeySize)
+	b, err := New(otherKey)
+	assert.NoError(t, err)
+	_, err = b.Open(nil, nonce, sealed, ad)
+	assert.Error(t, err)
+}
+
+func TestErrors(t *testing.T) {
+	_, err := New(make([]byte, KeySize-1))
+	assert.Error(t, err)
+
+	key, nonce := testKeyNonce()
+	a, err := New(key)
+	assert.NoError(t, err)
+
+	assert.That(t, panics(func() { a.Seal(nil, nonce[:NonceSize-1], nil, nil) }))
+	assert.That(t, panics(func() { _, _ = a.Open(nil, nonce[:NonceSize-1], nil, nil) }))
+}
+
+func panics(fn func()) (panicked bool) {
+	defer func() { panicked = recover() != nil }()
+	fn()
+	return false
+}
+
+func BenchmarkSeal(b *testing.B) {
+	key, nonce := testKeyNonce()
+	a, _ := New(key)
+
+	for _, n := range []int{64, 1024, 8192, 65536} {
+		plaintext := testInput(n)
+		buf := make([]byte, 0, n+Overhead)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			b.SetBytes(int64(n))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				a.Seal(buf, nonce, plaintext, nil)
+			}
+		})
+	}
+}
@@ -4,6 +4,10 @@ package blake3
 
 import (
 	"errors"
+	"io"
+	"math"
+	"os"
+	"unsafe"
 
 	"github.com/zeebo/blake3/internal/consts"
 	"github.com/zeebo/blake3/internal/utils"
@@ -55,9 +59,7 @@ func NewKeyed(key []byte) (*Hasher, error) {
 // format is "[application] [commit timestamp] [purpose]", e.g.,
 // "example.com 2019-12-25 16:18:03 session tokens v1".
 func DeriveKey(context string, material []byte, out []byte) {
-	h := NewDeriveKey(context)
-	_, _ = h.Write(material)
-	_, _ = h.Digest().Read(out)
+	deriveKey(context, material, out)
 }
 
 // NewDeriveKey returns a Hasher that is initialized with the context
@@ -97,17 +99,83 @@ func (h *Hasher) WriteString(p string) (int, error) {
 	return len(p), nil
 }
 
+// WriteParallel is like Write but hashes large inputs using up to n
+// goroutines. If n <= 0, runtime.GOMAXPROCS(0) goroutines are used. The
+// resulting state is identical to writing the same data with Write. It never
+// returns an error.
+func (h *Hasher) WriteParallel(p []byte, n int) (int, error) {
+	h.h.updateParallel(p, n)
+	return len(p), nil
+}
+
+// WriteReaderAt writes size bytes read from r starting at offset 0 into the
+// Hasher, issuing reads for disjoint regions from up to n goroutines. If
+// n <= 0, runtime.GOMAXPROCS(0) goroutines are used. The resulting state is
+// identical to writing the same data with Write.
+//
+// If an error is returned, the state of the Hasher is unspecified and it
+// must be Reset before being used again.
+func (h *Hasher) WriteReaderAt(r io.ReaderAt, size int64, n int) (int64, error) {
+	if err := h.h.updateReaderAt(r, size, n); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// ReadFrom implements io.ReaderFrom. It writes data from r into the Hasher
+// until EOF and returns the number of bytes written. A large regular *os.File
+// is memory mapped where supported and hashed without copying.
+func (h *Hasher) ReadFrom(r io.Reader) (int64, error) {
+	return h.h.readFrom(r)
+}
+
 // Reset implements part of the hash.Hash interface. It causes the Hasher to
 // act as if it was newly created.
 func (h *Hasher) Reset() {
 	h.h.reset()
 }
 
-// Clone returns a new Hasher with the same internal state.
+// Clone returns a new Hasher with the same internal state. Only the parts of
+// the buffer and stack that are in use are copied, so the cost of cloning is
+// proportional to the amount of state in use.
 //
 // Modifying the resulting Hasher will not modify the original Hasher, and vice versa.
 func (h *Hasher) Clone() *Hasher {
-	return &Hasher{size: h.size, h: h.h}
+	c := &Hasher{size: h.size}
+	h.h.copyTo(&c.h)
+	return c
+}
+
+// CloneInto is like Clone but sets dst to the state of h, reusing the memory
+// of dst instead of allocating.
+func (h *Hasher) CloneInto(dst *Hasher) {
+	if dst != h {
+		h.h.copyTo(&dst.h)
+	}
+	dst.size = h.size
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. It returns the state
+// of the Hasher so that it can be restored with UnmarshalBinary, possibly in
+// another process.
+func (h *Hasher) MarshalBinary() ([]byte, error) {
+	return h.AppendBinary(nil)
+}
+
+// AppendBinary is like MarshalBinary but appends the state to b.
+func (h *Hasher) AppendBinary(b []byte) ([]byte, error) {
+	return h.h.appendBinary(b, h.size), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It restores state
+// returned by MarshalBinary or AppendBinary.
+func (h *Hasher) UnmarshalBinary(b []byte) error {
+	size, err := h.h.unmarshalBinary(b)
+	if err != nil {
+		return err
+	}
+	h.size = size
+	return nil
 }
 
 // Size implements part of the hash.Hash interface. It returns the number of
@@ -143,12 +211,24 @@ func (h *Hasher) Digest() *Digest {
 	return &d
 }
 
+// DigestN is like Digest but limits the output stream to n bytes, after which
+// reads return io.EOF. It panics if n is larger than math.MaxInt64, the
+// largest offset io.Seeker can represent.
+func (h *Hasher) DigestN(n uint64) *LimitedDigest {
+	if n > math.MaxInt64 {
+		panic("blake3: DigestN length too large")
+	}
+	l := &LimitedDigest{n: n}
+	h.h.finalizeDigest(&l.d)
+	return l
+}
+
 // Sum256 returns the first 256 bits of the unkeyed digest of the data.
 func Sum256(data []byte) (sum [32]byte) {
 	if len(data) <= consts.ChunkLen {
-		sumSmall(data, sum[:])
+		sumSmall(data, 0, &consts.IV, sum[:])
 	} else {
-		sumLarge(data, sum[:])
+		sumLarge(data, 0, &consts.IV, sum[:])
 	}
 	return
 }
@@ -156,21 +236,121 @@ func Sum256(data []byte) (sum [32]byte) {
 // Sum512 returns the first 512 bits of the unkeyed digest of the data.
 func Sum512(data []byte) (sum [64]byte) {
 	if len(data) <= consts.ChunkLen {
-		sumSmall(data, sum[:])
+		sumSmall(data, 0, &consts.IV, sum[:])
+	} else {
+		sumLarge(data, 0, &consts.IV, sum[:])
+	}
+	return
+}
+
+// KeyedSum256 returns the first 256 bits of the digest of the data keyed
+// with key, the same as a Hasher returned by NewKeyed. Unlike NewKeyed, it
+// does not allocate.
+func KeyedSum256(key *[32]byte, data []byte) (sum [32]byte) {
+	var k [8]uint32
+	utils.KeyFromBytes(key[:], &k)
+	if len(data) <= consts.ChunkLen {
+		sumSmall(data, consts.Flag_Keyed, &k, sum[:])
+	} else {
+		sumLarge(data, consts.Flag_Keyed, &k, sum[:])
+	}
+	return
+}
+
+// KeyedSum512 returns the first 512 bits of the digest of the data keyed
+// with key. Like KeyedSum256, it does not allocate.
+func KeyedSum512(key *[32]byte, data []byte) (sum [64]byte) {
+	var k [8]uint32
+	utils.KeyFromBytes(key[:], &k)
+	if len(data) <= consts.ChunkLen {
+		sumSmall(data, consts.Flag_Keyed, &k, sum[:])
+	} else {
+		sumLarge(data, consts.Flag_Keyed, &k, sum[:])
+	}
+	return
+}
+
+// DeriveKey32 returns a 32 byte key derived from the key material in the
+// given context, the same as DeriveKey with a 32 byte output. It does not
+// allocate. See DeriveKey for details on choosing a context string.
+func DeriveKey32(context string, material []byte) (key [32]byte) {
+	deriveKey(context, material, key[:])
+	return
+}
+
+// SumParallel returns the first 256 bits of the unkeyed digest of the data,
+// hashing it using up to n goroutines. If n <= 0, runtime.GOMAXPROCS(0)
+// goroutines are used.
+func SumParallel(data []byte, n int) (sum [32]byte) {
+	if len(data) <= consts.ChunkLen {
+		sumSmall(data, 0, &consts.IV, sum[:])
 	} else {
-		sumLarge(data, sum[:])
+		h := hasher{key: consts.IV}
+		h.updateParallel(data, n)
+		h.finalize(sum[:])
 	}
 	return
 }
 
-func sumSmall(data []byte, out []byte) {
+// HashReaderAt returns the first 256 bits of the unkeyed digest of size
+// bytes read from r starting at offset 0, issuing reads from up to n
+// goroutines. If n <= 0, runtime.GOMAXPROCS(0) goroutines are used.
+//
+// Use WriteReaderAt for keyed hashes or for more output through Digest.
+func HashReaderAt(r io.ReaderAt, size int64, n int) (sum [32]byte, err error) {
+	h := hasher{key: consts.IV}
+	if err := h.updateReaderAt(r, size, n); err != nil {
+		return sum, err
+	}
+	h.finalize(sum[:])
+	return sum, nil
+}
+
+// HashFile returns the first 256 bits of the unkeyed digest of the contents
+// of the named file. Large regular files are memory mapped where supported.
+func HashFile(path string) (sum [32]byte, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return sum, err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := hasher{key: consts.IV}
+	if _, err := h.readFrom(f); err != nil {
+		return sum, err
+	}
+	h.finalize(sum[:])
+	return sum, nil
+}
+
+func deriveKey(context string, material []byte, out []byte) {
+	var buf [32]byte
+	var key [8]uint32
+
+	// the context is hashed in place of the key, and is never modified
+	ctx := unsafe.Slice(unsafe.StringData(context), len(context))
+	if len(ctx) <= consts.ChunkLen {
+		sumSmall(ctx, consts.Flag_DeriveKeyContext, &consts.IV, buf[:])
+	} else {
+		sumLarge(ctx, consts.Flag_DeriveKeyContext, &consts.IV, buf[:])
+	}
+	utils.KeyFromBytes(buf[:], &key)
+
+	if len(material) <= consts.ChunkLen {
+		sumSmall(material, consts.Flag_DeriveKeyMaterial, &key, out)
+	} else {
+		sumLarge(material, consts.Flag_DeriveKeyMaterial, &key, out)
+	}
+}
+
+func sumSmall(data []byte, flags uint32, key *[8]uint32, out []byte) {
 	var d Digest
-	compressAll(&d, data, 0, consts.IV)
+	compressAll(&d, data, flags, *key)
 	_, _ = d.Read(out[:])
 }
 
-func sumLarge(data []byte, out []byte) {
-	h := hasher{key: consts.IV}
-	h.update(data)
+func sumLarge(data []byte, flags uint32, key *[8]uint32, out []byte) {
+	h := hasher{flags: flags, key: *key}
+	h.updateFinal(data)
 	h.finalize(out[:])
 }
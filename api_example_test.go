@@ -161,3 +161,60 @@ func ExampleSum512() {
 	// b224a1da2bf5e72b337dc6dde457a05265a06dec8875be379e2ad2be5edb3bf2
 	// 1b55688951738e3a7155d6398eb56c6bc35d5bca5f139d98eb7409be51d1be32
 }
+
+func ExampleKeyedSum256() {
+	var key [32]byte
+	copy(key[:], bytes.Repeat([]byte("1"), 32))
+
+	digest := blake3.KeyedSum256(&key, []byte("some data"))
+
+	fmt.Printf("%x\n", digest[:])
+	//output:
+	// 107c6f88638356d73cdb80f4d56ffe50abcbd9664a80c8ab2b83b1f946ebaba1
+}
+
+func ExampleDeriveKey32() {
+	// See the documentation for good practices on what the context should be.
+	key := blake3.DeriveKey32(
+		"my-application v0.1.1 session tokens v1",  // context
+		[]byte("some material to derive key from"), // material
+	)
+
+	fmt.Printf("%x\n", key[:])
+	//output:
+	// 98a3333af735f89eb301b56eaf6a77713aa03cdb0057e5b04352a63ea9204add
+}
+
+func ExampleSumHash() {
+	hash := blake3.SumHash([]byte("some data"))
+	fmt.Println(hash)
+
+	parsed, err := blake3.ParseHash("b224a1da2bf5e72b337dc6dde457a05265a06dec8875be379e2ad2be5edb3bf2")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(parsed.Equal(hash))
+	//output:
+	// b224a1da2bf5e72b337dc6dde457a05265a06dec8875be379e2ad2be5edb3bf2
+	// true
+}
+
+func ExampleNewMAC() {
+	key := bytes.Repeat([]byte("1"), 32)
+
+	// a 16 byte tag is the first 16 bytes of the full keyed hash
+	m, err := blake3.NewMAC(key, 16)
+	if err != nil {
+		panic(err)
+	}
+	m.Write([]byte("some data"))
+	tag := m.Sum(nil)
+	fmt.Printf("%x\n", tag)
+
+	m.Reset()
+	m.Write([]byte("some data"))
+	fmt.Println(m.Verify(tag))
+	//output:
+	// 107c6f88638356d73cdb80f4d56ffe50
+	// true
+}
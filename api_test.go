@@ -3,19 +3,29 @@ package blake3
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"hash"
 	"io"
 	"runtime"
 	"strings"
+	"sync"
 	"testing"
+	"testing/iotest"
 
 	"github.com/zeebo/assert"
 )
 
-// Make sure Hasher implements hash.Hash and Digest implements io.ReadSeeker.
+// Make sure Hasher implements hash.Hash and io.ReaderFrom, and Digest implements
+// io.ReadSeeker.
 var _ hash.Hash = (*Hasher)(nil)
 var _ io.ReadSeeker = (*Digest)(nil)
+var _ io.ReaderAt = (*Digest)(nil)
+var _ io.WriterTo = (*Digest)(nil)
+var _ io.ReadSeeker = (*LimitedDigest)(nil)
+var _ io.ReaderAt = (*LimitedDigest)(nil)
+var _ io.WriterTo = (*LimitedDigest)(nil)
+var _ io.ReaderFrom = (*Hasher)(nil)
 
 func TestAPI_Vectors(t *testing.T) {
 	check := func(t *testing.T, h *Hasher, input []byte, hash string) {
@@ -276,6 +286,48 @@ func TestSum512(t *testing.T) {
 	}
 }
 
+func TestKeyedSum(t *testing.T) {
+	var key [32]byte
+	copy(key[:], testVectorKey)
+
+	for _, tv := range vectors {
+		sum256 := KeyedSum256(&key, tv.input())
+		sum512 := KeyedSum512(&key, tv.input())
+		assert.Equal(t, hex.EncodeToString(sum256[:]), tv.keyedHash[:64])
+		assert.Equal(t, hex.EncodeToString(sum512[:]), tv.keyedHash[:128])
+	}
+}
+
+func TestDeriveKey32(t *testing.T) {
+	for _, tv := range vectors {
+		got := DeriveKey32(testVectorContext, tv.input())
+		assert.Equal(t, hex.EncodeToString(got[:]), tv.deriveKey[:64])
+
+		out := make([]byte, len(tv.deriveKey)/2)
+		DeriveKey(testVectorContext, tv.input(), out)
+		assert.Equal(t, hex.EncodeToString(out), tv.deriveKey)
+	}
+
+	// long contexts are hashed like any other input
+	context := strings.Repeat("context ", 200)
+	exp := make([]byte, 32)
+	h := NewDeriveKey(context)
+	_, _ = h.WriteString("material")
+	_, _ = h.Digest().Read(exp)
+	got := DeriveKey32(context, []byte("material"))
+	assert.Equal(t, hex.EncodeToString(got[:]), hex.EncodeToString(exp))
+}
+
+func TestKeyedSum_Allocs(t *testing.T) {
+	var key [32]byte
+	for _, n := range []int{100, 100000} {
+		data := make([]byte, n)
+		assert.Equal(t, testing.AllocsPerRun(10, func() { _ = KeyedSum256(&key, data) }), 0.0)
+		assert.Equal(t, testing.AllocsPerRun(10, func() { _ = KeyedSum512(&key, data) }), 0.0)
+		assert.Equal(t, testing.AllocsPerRun(10, func() { _ = DeriveKey32("context", data) }), 0.0)
+	}
+}
+
 func TestClone(t *testing.T) {
 	sum := func(h *Hasher) string { return hex.EncodeToString(h.Sum(nil)) }
 
@@ -295,6 +347,252 @@ func TestClone(t *testing.T) {
 	assert.Equal(t, sum(h1), sum(h2))
 }
 
+func TestCloneInto(t *testing.T) {
+	x := make([]byte, 100000)
+	for i := range x {
+		x[i] = byte(i) % 251
+	}
+	sum := func(h *Hasher) string { return hex.EncodeToString(h.Sum(nil)) }
+
+	for _, n := range []int{0, 1, 1024, 8192, 8193, 50000} {
+		h := New()
+		_, _ = h.Write(x[:n])
+		exp := sum(h)
+
+		// into an unrelated hasher that owns its state
+		dst, _ := NewKeyed(make([]byte, 32))
+		_, _ = dst.Write(x)
+		h.CloneInto(dst)
+		assert.Equal(t, sum(dst), exp)
+
+		// into a hasher that was just cloned
+		other := dst.Clone()
+		h.CloneInto(dst)
+		assert.Equal(t, sum(dst), exp)
+		assert.Equal(t, sum(other), exp)
+
+		// into the zero value
+		var zero Hasher
+		h.CloneInto(&zero)
+		assert.Equal(t, sum(&zero), exp)
+
+		// all of them diverge independently
+		_, _ = dst.Write(x[n:])
+		_, _ = zero.WriteString("zero")
+		other.Reset()
+		assert.Equal(t, sum(h), exp)
+		full := Sum256(x)
+		assert.Equal(t, sum(dst), hex.EncodeToString(full[:]))
+		assert.Equal(t, sum(other), sum(New()))
+
+		h.CloneInto(h)
+		assert.Equal(t, sum(h), exp)
+	}
+}
+
+func TestHasher_Copy(t *testing.T) {
+	h := New()
+	_, _ = h.WriteString("some")
+
+	// a copy of the value is an independent snapshot, like a Clone
+	c := *h
+	_, _ = c.WriteString(" data")
+	exp := Sum256([]byte("some"))
+	assert.Equal(t, hex.EncodeToString(h.Sum(nil)), hex.EncodeToString(exp[:]))
+	exp = Sum256([]byte("some data"))
+	assert.Equal(t, hex.EncodeToString(c.Sum(nil)), hex.EncodeToString(exp[:]))
+}
+
+func TestClone_Allocs(t *testing.T) {
+	h := New()
+	_, _ = h.Write(make([]byte, 10000))
+	dst := New()
+
+	var c *Hasher
+	assert.Equal(t, testing.AllocsPerRun(100, func() { c = New() }), 1.0)
+	assert.Equal(t, testing.AllocsPerRun(100, func() { c = h.Clone() }), 1.0)
+	assert.Equal(t, c.Size(), 32)
+	assert.Equal(t, testing.AllocsPerRun(100, func() { h.CloneInto(dst) }), 0.0)
+}
+
+func TestClone_Concurrent(t *testing.T) {
+	h := New()
+	_, _ = h.Write(make([]byte, 10000))
+	exp := Sum256(make([]byte, 20000))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		c := h.Clone()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = c.Write(make([]byte, 10000))
+			assert.Equal(t, hex.EncodeToString(c.Sum(nil)), hex.EncodeToString(exp[:]))
+		}()
+	}
+	_, _ = h.Write(make([]byte, 10000))
+	wg.Wait()
+
+	assert.Equal(t, hex.EncodeToString(h.Sum(nil)), hex.EncodeToString(exp[:]))
+}
+
+func TestDigest_ReadLarge(t *testing.T) {
+	h := New()
+	_, _ = h.WriteString("large output")
+
+	read := func(d *Digest, p []byte, size int) {
+		for len(p) > size {
+			_, _ = d.Read(p[:size])
+			p = p[size:]
+		}
+		_, _ = d.Read(p)
+	}
+
+	// the counter crosses into the high word while reading from here
+	for _, start := range []int64{0, 5, 64*(1<<32-7) + 3} {
+		exp := make([]byte, 5000)
+		d := h.Digest()
+		_, _ = d.Seek(start, io.SeekStart)
+		read(d, exp, 63)
+
+		for _, size := range []int{1024, 1025, 2048 + 100, 5000} {
+			got := make([]byte, 5000)
+			d := h.Digest()
+			_, _ = d.Seek(start, io.SeekStart)
+			read(d, got, size)
+			assert.Equal(t, hex.EncodeToString(got), hex.EncodeToString(exp))
+
+			// the position is the same as after reading in small pieces
+			pos, _ := d.Seek(0, io.SeekCurrent)
+			assert.Equal(t, pos, start+5000)
+		}
+	}
+}
+
+func TestDigest_ReadAt(t *testing.T) {
+	h := New()
+	_, _ = h.WriteString("random access")
+
+	exp := make([]byte, 10000)
+	_, _ = h.Digest().Read(exp)
+
+	d := h.Digest()
+	_, _ = d.Seek(77, io.SeekStart)
+
+	for _, off := range []int{0, 1, 63, 64, 1000, 5000} {
+		for _, size := range []int{0, 1, 64, 1024, 3000} {
+			got := make([]byte, size)
+			n, err := d.ReadAt(got, int64(off))
+			assert.NoError(t, err)
+			assert.Equal(t, n, size)
+			assert.Equal(t, hex.EncodeToString(got), hex.EncodeToString(exp[off:off+size]))
+		}
+	}
+
+	// the position is unchanged
+	pos, _ := d.Seek(0, io.SeekCurrent)
+	assert.Equal(t, pos, 77)
+
+	_, err := d.ReadAt(make([]byte, 1), -1)
+	assert.Error(t, err)
+}
+
+type limitWriter struct {
+	buf []byte
+	n   int
+}
+
+func (l *limitWriter) Write(p []byte) (int, error) {
+	if len(p) > l.n-len(l.buf) {
+		p = p[:l.n-len(l.buf)]
+		l.buf = append(l.buf, p...)
+		return len(p), errors.New("limit reached")
+	}
+	l.buf = append(l.buf, p...)
+	return len(p), nil
+}
+
+func TestDigest_WriteTo(t *testing.T) {
+	h := New()
+	_, _ = h.WriteString("streaming")
+
+	exp := make([]byte, 200000)
+	_, _ = h.Digest().Read(exp)
+
+	for _, start := range []int{0, 1, 64, 100} {
+		for _, limit := range []int{0, 1, 1000, 32 * 1024, 100000} {
+			d := h.Digest()
+			_, _ = d.Seek(int64(start), io.SeekStart)
+
+			w := &limitWriter{n: limit}
+			n, err := d.WriteTo(w)
+			assert.Error(t, err)
+			assert.Equal(t, n, limit)
+			assert.Equal(t, hex.EncodeToString(w.buf), hex.EncodeToString(exp[start:start+limit]))
+
+			// reading continues after the last byte written
+			next := make([]byte, 10)
+			_, _ = d.Read(next)
+			assert.Equal(t, hex.EncodeToString(next), hex.EncodeToString(exp[start+limit:start+limit+10]))
+		}
+	}
+}
+
+func TestDigestN(t *testing.T) {
+	h := New()
+	_, _ = h.WriteString("bounded")
+
+	for _, size := range []int{0, 1, 64, 100, 1024, 5000} {
+		exp := make([]byte, size)
+		_, _ = h.Digest().Read(exp)
+
+		assert.NoError(t, iotest.TestReader(h.DigestN(uint64(size)), exp))
+
+		d := h.DigestN(uint64(size))
+		assert.Equal(t, d.Size(), int64(size))
+
+		got, err := io.ReadAll(d)
+		assert.NoError(t, err)
+		assert.Equal(t, hex.EncodeToString(got), hex.EncodeToString(exp))
+
+		pos, err := d.Seek(-int64(size)/2, io.SeekEnd)
+		assert.NoError(t, err)
+		assert.Equal(t, pos, int64(size-size/2))
+
+		var buf bytes.Buffer
+		n, err := d.WriteTo(&buf)
+		assert.NoError(t, err)
+		assert.Equal(t, n, int64(size/2))
+		assert.Equal(t, hex.EncodeToString(buf.Bytes()), hex.EncodeToString(exp[size-size/2:]))
+
+		sr := io.NewSectionReader(d, 0, d.Size())
+		got, err = io.ReadAll(sr)
+		assert.NoError(t, err)
+		assert.Equal(t, hex.EncodeToString(got), hex.EncodeToString(exp))
+	}
+
+	d := h.DigestN(100)
+	n, err := d.ReadAt(make([]byte, 64), 50)
+	assert.Equal(t, n, 50)
+	assert.Equal(t, err, io.EOF)
+
+	_, err = d.Seek(-101, io.SeekEnd)
+	assert.Error(t, err)
+}
+
+func BenchmarkDigest_Read(b *testing.B) {
+	buf := make([]byte, 1024*1024)
+	d := New().Digest()
+
+	b.SetBytes(int64(len(buf)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = d.Read(buf)
+	}
+}
+
 func BenchmarkSum256(b *testing.B) {
 	run := func(b *testing.B, size int64) {
 		b.Run(fmt.Sprint(size), func(b *testing.B) {
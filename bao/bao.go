@@ -0,0 +1,282 @@
+// Package bao implements the Bao verified streaming format on top of the
+// BLAKE3 tree. An encoding stores the parent nodes of the tree alongside the
+// content, so that a reader holding only the root hash can verify each leaf
+// of the tree before using it.
+//
+// The combined encoding is an 8 byte little endian content length followed by
+// the tree in pre-order: each parent node is the 64 bytes of the chaining
+// values of its children, and each leaf is the content itself. The root
+// hash of an encoding is the BLAKE3 hash of the content.
+//
+// The package level functions use the standard Bao tree, whose leaves are
+// single chunks. A Tree with larger leaves stores fewer parent nodes at the
+// cost of verifying more content at a time.
+package bao
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/zeebo/blake3"
+	"github.com/zeebo/blake3/hazmat"
+)
+
+const (
+	// ChunkLen is the size of the chunks in the BLAKE3 tree.
+	ChunkLen = hazmat.ChunkLen
+
+	// MaxChunkGroupLog is the largest ChunkGroupLog of a Tree.
+	MaxChunkGroupLog = 10
+
+	// HeaderSize is the size of the content length that begins an encoding.
+	HeaderSize = 8
+
+	// ParentSize is the size of a parent node in an encoding.
+	ParentSize = 64
+)
+
+// HashMismatchError is returned when part of an encoding does not match the
+// root hash. Content before Offset has been verified.
+type HashMismatchError struct {
+	Offset uint64 // offset into the content of the subtree that failed
+}
+
+func (e *HashMismatchError) Error() string {
+	return fmt.Sprintf("hash mismatch at offset %d", e.Offset)
+}
+
+// Tree selects the size of the leaves of the tree stored in an encoding.
+// The zero Tree is the standard Bao tree, whose leaves are single chunks.
+//
+// A Tree whose leaves are groups of chunks keeps only the upper levels of the
+// BLAKE3 tree, so every doubling of the group halves the space taken by the
+// parent nodes. The root hash is still the BLAKE3 hash of the
+// content, but an encoding can only be read with the Tree it was made with.
+type Tree struct {
+	// ChunkGroupLog is the base 2 logarithm of the number of chunks in each
+	// leaf, at most MaxChunkGroupLog.
+	ChunkGroupLog uint8
+}
+
+// EncodedSize returns the size of the combined encoding of size bytes of
+// content.
+func EncodedSize(size uint64) uint64 { return Tree{}.EncodedSize(size) }
+
+// EncodedSize returns the size of the combined encoding of size bytes of
+// content.
+func (t Tree) EncodedSize(size uint64) uint64 {
+	return HeaderSize + t.treeSize(size) + size
+}
+
+//
+// tree helpers
+//
+
+// leafLen returns the size of the leaves of the tree.
+func (t Tree) leafLen() uint64 {
+	if t.ChunkGroupLog > MaxChunkGroupLog {
+		panic("bao: chunk group log too large")
+	}
+	return ChunkLen << t.ChunkGroupLog
+}
+
+// leaves returns the number of leaves in the tree of size bytes of content.
+// Empty content still has a single, empty leaf.
+func (t Tree) leaves(size uint64) uint64 {
+	if size == 0 {
+		return 1
+	}
+	return (size-1)/t.leafLen() + 1
+}
+
+// treeSize returns the size of the parent nodes of the tree of size bytes of
+// content.
+func (t Tree) treeSize(size uint64) uint64 {
+	return ParentSize * (t.leaves(size) - 1)
+}
+
+// leftLen returns the size of the content in the left subtree of a subtree
+// of size bytes, which must be larger than a leaf. It is always a whole
+// number of leaves.
+func leftLen(size uint64) uint64 {
+	return hazmat.LeftSubtreeLen(size)
+}
+
+func appendHeader(b []byte, size uint64) []byte {
+	return binary.LittleEndian.AppendUint64(b, size)
+}
+
+func readHeader(r io.Reader) (uint64, error) {
+	var buf [HeaderSize]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	return binary.LittleEndian.Uint64(buf[:]), nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// node describes a subtree of the content.
+type node struct {
+	start uint64               // offset into the content
+	size  uint64               // size of the content
+	cv    hazmat.ChainingValue // expected chaining value, unless root
+	root  bool                 // if the subtree is the whole tree
+}
+
+var mode = hazmat.Hash()
+
+// leafCV returns the chaining value of the leaf at offset start.
+func leafCV(start uint64, leaf []byte) hazmat.ChainingValue {
+	// leaves are always aligned, non-empty and complete subtrees
+	cv, _ := hazmat.SubtreeChainingValue(mode, start, leaf)
+	return cv
+}
+
+// verifyLeaf reports if the leaf matches the expected chaining value of the
+// node, or the root hash if the node is the root.
+func (n *node) verifyLeaf(leaf []byte, hash *[32]byte) bool {
+	if n.root {
+		return blake3.Sum256(leaf) == *hash
+	}
+	return leafCV(n.start, leaf) == n.cv
+}
+
+// split returns the nodes for the left and right subtrees described by the
+// parent node.
+func (n *node) split(parent *[ParentSize]byte) (left, right node) {
+	l := leftLen(n.size)
+	left = node{start: n.start, size: l}
+	right = node{start: n.start + l, size: n.size - l}
+	copy(left.cv[:], parent[:32])
+	copy(right.cv[:], parent[32:])
+	return left, right
+}
+
+// verifyParent reports if the chaining values of the left and right subtrees
+// match the expected chaining value of the node, or the root hash if the
+// node is the root.
+func (n *node) verifyParent(left, right *node, hash *[32]byte) bool {
+	if n.root {
+		return hazmat.MergeSubtreesRoot(&left.cv, &right.cv, mode) == *hash
+	}
+	return hazmat.MergeSubtreesNonRoot(&left.cv, &right.cv, mode) == n.cv
+}
+
+// sliceRange returns the range of content covered by the slice of length
+// bytes from start, clipped to the content. The range always includes at
+// least one leaf so that a slice verifies the content length: the leaf at
+// start, or the last leaf if start is past the end.
+func sliceRange(size, start, length uint64) (lo, hi uint64) {
+	if size == 0 {
+		return 0, 0
+	}
+	lo = start
+	if lo >= size {
+		lo = size - 1
+	}
+	switch {
+	case length == 0:
+		hi = lo + 1
+	case length > size-lo:
+		hi = size
+	default:
+		hi = lo + length
+	}
+	return lo, hi
+}
+
+func readAtFull(r io.ReaderAt, p []byte, off uint64) error {
+	n, err := r.ReadAt(p, int64(off))
+	if n == len(p) {
+		return nil
+	} else if err == nil || err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+//
+// random access to encodings
+//
+
+// source reads the parts of an encoding that are needed for a range of the
+// content.
+type source struct {
+	tree    Tree
+	enc     io.ReaderAt // the encoding, including the header
+	content io.ReaderAt // the content, or nil if enc is a combined encoding
+}
+
+// visitor receives the parent nodes and leaves visited by walk in pre-order.
+type visitor struct {
+	parent func(parent *[ParentSize]byte) error // may be nil
+	leaf   func(start uint64, leaf []byte) error
+
+	buf []byte // reused for every leaf
+}
+
+// encodedSize returns the size of the encoding of a subtree of size bytes.
+func (s *source) encodedSize(size uint64) uint64 {
+	if s.content == nil {
+		return s.tree.treeSize(size) + size
+	}
+	return s.tree.treeSize(size)
+}
+
+func (s *source) readLeaf(n *node, pos uint64, leaf []byte) error {
+	if s.content == nil {
+		return readAtFull(s.enc, leaf, HeaderSize+pos)
+	}
+	return readAtFull(s.content, leaf, n.start)
+}
+
+// walk visits the subtree n, whose encoding begins at pos, and every node
+// below it that is needed for the content in [lo, hi). If hash is not nil,
+// every visited node is verified against it before being passed to v. The
+// root is always visited, so an empty range visits only the root node.
+func (s *source) walk(n node, pos, lo, hi uint64, hash *[32]byte, v *visitor) error {
+	if n.size <= s.tree.leafLen() {
+		if uint64(cap(v.buf)) < n.size {
+			v.buf = make([]byte, s.tree.leafLen())
+		}
+		leaf := v.buf[:n.size]
+		if err := s.readLeaf(&n, pos, leaf); err != nil {
+			return err
+		}
+		if hash != nil && !n.verifyLeaf(leaf, hash) {
+			return &HashMismatchError{Offset: n.start}
+		}
+		return v.leaf(n.start, leaf)
+	}
+
+	var parent [ParentSize]byte
+	if err := readAtFull(s.enc, parent[:], HeaderSize+pos); err != nil {
+		return err
+	}
+	left, right := n.split(&parent)
+	if hash != nil && !n.verifyParent(&left, &right, hash) {
+		return &HashMismatchError{Offset: n.start}
+	}
+	if v.parent != nil {
+		if err := v.parent(&parent); err != nil {
+			return err
+		}
+	}
+
+	if lo < right.start {
+		if err := s.walk(left, pos+ParentSize, lo, hi, hash, v); err != nil {
+			return err
+		}
+	}
+	if hi > right.start {
+		return s.walk(right, pos+ParentSize+s.encodedSize(left.size), lo, hi, hash, v)
+	}
+	return nil
+}
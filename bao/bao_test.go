@@ -0,0 +1,118 @@
+package bao
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/zeebo/assert"
+	"github.com/zeebo/blake3"
+	"github.com/zeebo/blake3/hazmat"
+)
+
+var testSizes = []int{
+	0, 1, ChunkLen - 1, ChunkLen, ChunkLen + 1,
+	2 * ChunkLen, 2*ChunkLen + 1, 3 * ChunkLen, 8*ChunkLen + 1,
+	100*ChunkLen + 7,
+}
+
+func testInput(n int) []byte {
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = uint8(i % 251)
+	}
+	return out
+}
+
+func encode(t *testing.T, content []byte) ([]byte, [32]byte) {
+	var buf bytes.Buffer
+	hash, err := Encode(&buf, bytes.NewReader(content), int64(len(content)))
+	assert.NoError(t, err)
+	return buf.Bytes(), hash
+}
+
+func TestEncode(t *testing.T) {
+	for _, size := range testSizes {
+		content := testInput(size)
+		enc, hash := encode(t, content)
+
+		assert.Equal(t, hash, blake3.Sum256(content))
+		assert.Equal(t, uint64(len(enc)), EncodedSize(uint64(size)))
+		assert.Equal(t, binary.LittleEndian.Uint64(enc), uint64(size))
+
+		got, err := io.ReadAll(NewDecoder(bytes.NewReader(enc), hash))
+		assert.NoError(t, err)
+		assert.That(t, bytes.Equal(got, content))
+	}
+}
+
+func TestEncode_Layout(t *testing.T) {
+	// three chunks have a root with a parent of two chunks on the left
+	content := testInput(3 * ChunkLen)
+	enc, _ := encode(t, content)
+
+	cv := func(start int, chunk []byte) []byte {
+		cv, err := hazmat.SubtreeChainingValue(hazmat.Hash(), uint64(start), chunk)
+		assert.NoError(t, err)
+		return cv[:]
+	}
+	c0 := cv(0, content[:ChunkLen])
+	c1 := cv(ChunkLen, content[ChunkLen:2*ChunkLen])
+	c2 := cv(2*ChunkLen, content[2*ChunkLen:])
+	l := cv(0, content[:2*ChunkLen])
+
+	var exp []byte
+	exp = appendHeader(exp, uint64(len(content)))
+	exp = append(append(exp, l...), c2...)
+	exp = append(append(exp, c0...), c1...)
+	exp = append(exp, content...)
+
+	assert.That(t, bytes.Equal(enc, exp))
+}
+
+func TestDecoder_Tampered(t *testing.T) {
+	content := testInput(3*ChunkLen + 5)
+	enc, hash := encode(t, content)
+
+	for i := range enc {
+		bad := append([]byte(nil), enc...)
+		bad[i] ^= 1
+
+		got, err := io.ReadAll(NewDecoder(bytes.NewReader(bad), hash))
+		assert.Error(t, err)
+		assert.That(t, bytes.HasPrefix(content, got))
+	}
+
+	for i := 0; i < len(enc); i++ {
+		_, err := io.ReadAll(NewDecoder(bytes.NewReader(enc[:i]), hash))
+		assert.Equal(t, err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestDecoder_Mismatch(t *testing.T) {
+	content := testInput(5 * ChunkLen)
+	enc, hash := encode(t, content)
+
+	// corrupt the last chunk so that the first four are returned
+	enc[len(enc)-1] ^= 1
+
+	d := NewDecoder(bytes.NewReader(enc), hash)
+	got, err := io.ReadAll(d)
+	assert.That(t, bytes.Equal(got, content[:4*ChunkLen]))
+
+	var mismatch *HashMismatchError
+	assert.That(t, errors.As(err, &mismatch))
+	assert.Equal(t, mismatch.Offset, uint64(4*ChunkLen))
+
+	// the error is sticky
+	_, err2 := d.Read(make([]byte, 1))
+	assert.Equal(t, err2, err)
+
+	// a different root hash fails on the root node
+	hash[0] ^= 1
+	_, err = io.ReadAll(NewDecoder(bytes.NewReader(enc), hash))
+	assert.That(t, errors.As(err, &mismatch))
+	assert.Equal(t, mismatch.Offset, uint64(0))
+}
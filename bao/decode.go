@@ -0,0 +1,153 @@
+package bao
+
+import (
+	"io"
+)
+
+// Decoder reads the content of a combined encoding or a slice, verifying
+// each leaf against the root hash before returning any of its bytes.
+type Decoder struct {
+	tree   Tree
+	r      io.Reader
+	hash   [32]byte
+	start  uint64 // requested range of content
+	length uint64
+	slice  bool
+
+	init   bool
+	lo, hi uint64 // range of content whose nodes are in the encoding
+	stack  []node
+	buf    []byte
+	bufn   int // number of verified bytes in buf
+	bufo   int // offset of the unread bytes in buf
+	err    error
+}
+
+// NewDecoder returns a Decoder reading the combined encoding from r and
+// verifying it against the root hash.
+func NewDecoder(r io.Reader, hash [32]byte) *Decoder {
+	return Tree{}.NewDecoder(r, hash)
+}
+
+// NewDecoder is like the package level NewDecoder but uses the tree t.
+func (t Tree) NewDecoder(r io.Reader, hash [32]byte) *Decoder {
+	return &Decoder{tree: t, r: r, hash: hash}
+}
+
+// NewSliceDecoder returns a Decoder reading the slice from r, as extracted
+// by ExtractSlice with the same start and length, and verifying it against
+// the root hash. It returns the content in the slice, which is shorter than
+// length if the slice extends past the end of the content. Like the slice,
+// the content length is only verified if the range includes the last leaf.
+func NewSliceDecoder(r io.Reader, hash [32]byte, start, length uint64) *Decoder {
+	return Tree{}.NewSliceDecoder(r, hash, start, length)
+}
+
+// NewSliceDecoder is like the package level NewSliceDecoder but uses the
+// tree t.
+func (t Tree) NewSliceDecoder(r io.Reader, hash [32]byte, start, length uint64) *Decoder {
+	return &Decoder{tree: t, r: r, hash: hash, start: start, length: length, slice: true}
+}
+
+// Read implements io.Reader. It only returns content that has been verified.
+// If the encoding does not match the root hash, it returns a
+// *HashMismatchError, and every later call returns the same error.
+func (d *Decoder) Read(p []byte) (n int, err error) {
+	for len(p) > 0 {
+		if d.bufo == d.bufn {
+			if n > 0 {
+				return n, nil
+			}
+			if d.err != nil {
+				return 0, d.err
+			}
+			d.err = d.next()
+			continue
+		}
+
+		m := copy(p, d.buf[d.bufo:d.bufn])
+		d.bufo += m
+		p = p[m:]
+		n += m
+	}
+	return n, nil
+}
+
+// next reads and verifies parent nodes until it reaches a leaf, and then
+// reads and verifies that leaf into buf, leaving only the requested content
+// to be read.
+func (d *Decoder) next() error {
+	if !d.init {
+		size, err := readHeader(d.r)
+		if err != nil {
+			return err
+		}
+		d.lo, d.hi = 0, size
+		if d.slice {
+			d.lo, d.hi = sliceRange(size, d.start, d.length)
+		}
+		d.stack = append(d.stack, node{size: size, root: true})
+		d.init = true
+	}
+
+	for len(d.stack) > 0 {
+		n := d.stack[len(d.stack)-1]
+		d.stack = d.stack[:len(d.stack)-1]
+
+		if n.size <= d.tree.leafLen() {
+			if d.buf == nil {
+				d.buf = make([]byte, d.tree.leafLen())
+			}
+			leaf := d.buf[:n.size]
+			if _, err := io.ReadFull(d.r, leaf); err != nil {
+				return unexpectedEOF(err)
+			}
+			if !n.verifyLeaf(leaf, &d.hash) {
+				return &HashMismatchError{Offset: n.start}
+			}
+			d.bufo, d.bufn = d.trim(&n)
+			return nil
+		}
+
+		var parent [ParentSize]byte
+		if _, err := io.ReadFull(d.r, parent[:]); err != nil {
+			return unexpectedEOF(err)
+		}
+		left, right := n.split(&parent)
+		if !n.verifyParent(&left, &right, &d.hash) {
+			return &HashMismatchError{Offset: n.start}
+		}
+
+		if d.hi > right.start {
+			d.stack = append(d.stack, right)
+		}
+		if d.lo < right.start {
+			d.stack = append(d.stack, left)
+		}
+	}
+
+	return io.EOF
+}
+
+// trim returns the range of the leaf n that holds requested content.
+func (d *Decoder) trim(n *node) (from, to int) {
+	if !d.slice {
+		return 0, int(n.size)
+	}
+
+	lo, hi := d.start, d.start+d.length
+	if hi < lo {
+		hi = ^uint64(0)
+	}
+	end := n.start + n.size
+	if lo < n.start {
+		lo = n.start
+	}
+	if hi > end {
+		hi = end
+	}
+	if lo >= hi {
+		return 0, 0
+	}
+	return int(lo - n.start), int(hi - n.start)
+}
@@ -0,0 +1,136 @@
+package bao
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	"github.com/zeebo/blake3"
+	"github.com/zeebo/blake3/hazmat"
+)
+
+// Encode writes the combined encoding of size bytes of content read from r
+// to w and returns the root hash. The content is read twice: once to compute
+// the tree and once more to interleave it with the tree.
+func Encode(w io.Writer, r io.ReaderAt, size int64) (hash [32]byte, err error) {
+	return Tree{}.Encode(w, r, size)
+}
+
+// Encode is like the package level Encode but uses the tree t.
+func (t Tree) Encode(w io.Writer, r io.ReaderAt, size int64) (hash [32]byte, err error) {
+	if size < 0 {
+		return hash, errors.New("negative size")
+	}
+
+	nodes, hash, err := t.buildTree(io.NewSectionReader(r, 0, size), uint64(size))
+	if err != nil {
+		return hash, err
+	}
+
+	bw := bufio.NewWriterSize(w, 64*1024)
+	br := bufio.NewReaderSize(io.NewSectionReader(r, 0, size), 64*1024)
+
+	if _, err := bw.Write(appendHeader(nil, uint64(size))); err != nil {
+		return hash, err
+	}
+	if err := t.writeCombined(bw, br, nodes, uint64(size)); err != nil {
+		return hash, err
+	}
+	return hash, bw.Flush()
+}
+
+// writeCombined writes the pre-order interleaving of the parent nodes in
+// nodes with the size bytes of content read from r.
+func (t Tree) writeCombined(w io.Writer, r io.Reader, nodes []byte, size uint64) error {
+	if size <= t.leafLen() {
+		_, err := io.CopyN(w, r, int64(size))
+		return unexpectedEOF(err)
+	}
+
+	l := leftLen(size)
+	if _, err := w.Write(nodes[:ParentSize]); err != nil {
+		return err
+	}
+	nodes = nodes[ParentSize:]
+
+	if err := t.writeCombined(w, r, nodes[:t.treeSize(l)], l); err != nil {
+		return err
+	}
+	return t.writeCombined(w, r, nodes[t.treeSize(l):], size-l)
+}
+
+// buildTree reads size bytes of content from r and returns the parent nodes
+// of its tree in pre-order along with the root hash.
+func (t Tree) buildTree(r io.Reader, size uint64) (nodes []byte, hash [32]byte, err error) {
+	b := treeBuilder{tree: t, r: bufio.NewReaderSize(r, 64*1024)}
+	nodes = make([]byte, t.treeSize(size))
+
+	if size <= t.leafLen() {
+		leaf, err := b.read(size)
+		if err != nil {
+			return nil, hash, err
+		}
+		return nodes, blake3.Sum256(leaf), nil
+	}
+
+	left, right, err := b.children(0, size, nodes)
+	if err != nil {
+		return nil, hash, err
+	}
+	return nodes, hazmat.MergeSubtreesRoot(&left, &right, mode), nil
+}
+
+type treeBuilder struct {
+	tree Tree
+	r    io.Reader
+	buf  []byte
+}
+
+// read reads the next leaf of size bytes.
+func (b *treeBuilder) read(size uint64) ([]byte, error) {
+	if b.buf == nil {
+		b.buf = make([]byte, b.tree.leafLen())
+	}
+	if _, err := io.ReadFull(b.r, b.buf[:size]); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return b.buf[:size], nil
+}
+
+// subtree reads the content of the subtree at offset start and stores its
+// parent nodes in nodes, returning its chaining value.
+func (b *treeBuilder) subtree(start, size uint64, nodes []byte) (hazmat.ChainingValue, error) {
+	if size <= b.tree.leafLen() {
+		leaf, err := b.read(size)
+		if err != nil {
+			return hazmat.ChainingValue{}, err
+		}
+		return leafCV(start, leaf), nil
+	}
+
+	left, right, err := b.children(start, size, nodes)
+	if err != nil {
+		return hazmat.ChainingValue{}, err
+	}
+	return hazmat.MergeSubtreesNonRoot(&left, &right, mode), nil
+}
+
+// children computes the chaining values of the children of the subtree at
+// offset start and stores them as its parent node at the front of nodes.
+func (b *treeBuilder) children(start, size uint64, nodes []byte) (left, right hazmat.ChainingValue, err error) {
+	l := leftLen(size)
+	rest := nodes[ParentSize:]
+
+	left, err = b.subtree(start, l, rest[:b.tree.treeSize(l)])
+	if err != nil {
+		return left, right, err
+	}
+	right, err = b.subtree(start+l, size-l, rest[b.tree.treeSize(l):])
+	if err != nil {
+		return left, right, err
+	}
+
+	copy(nodes[:32], left[:])
+	copy(nodes[32:ParentSize], right[:])
+	return left, right, nil
+}
@@ -0,0 +1,142 @@
+package bao
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// EncodeOutboard writes the outboard encoding of size bytes of content read
+// from r to w and returns the root hash. The outboard encoding is the
+// combined encoding without the content: the 8 byte content length followed
+// by the parent nodes of the tree in pre-order.
+func EncodeOutboard(w io.Writer, r io.Reader, size int64) (hash [32]byte, err error) {
+	return Tree{}.EncodeOutboard(w, r, size)
+}
+
+// EncodeOutboard is like the package level EncodeOutboard but uses the tree
+// t.
+func (t Tree) EncodeOutboard(w io.Writer, r io.Reader, size int64) (hash [32]byte, err error) {
+	if size < 0 {
+		return hash, errors.New("negative size")
+	}
+
+	nodes, hash, err := t.buildTree(r, uint64(size))
+	if err != nil {
+		return hash, err
+	}
+
+	if _, err := w.Write(appendHeader(nil, uint64(size))); err != nil {
+		return hash, err
+	}
+	if _, err := w.Write(nodes); err != nil {
+		return hash, err
+	}
+	return hash, nil
+}
+
+// OutboardSize returns the size of the outboard encoding of size bytes of
+// content.
+func OutboardSize(size uint64) uint64 { return Tree{}.OutboardSize(size) }
+
+// OutboardSize returns the size of the outboard encoding of size bytes of
+// content.
+func (t Tree) OutboardSize(size uint64) uint64 {
+	return HeaderSize + t.treeSize(size)
+}
+
+// OutboardReader reads content that is verified against the root hash using
+// its outboard encoding. Only the parent nodes needed for each read are
+// used, so reads anywhere in the content are cheap.
+//
+// It is safe for concurrent use if the content and outboard encoding are.
+type OutboardReader struct {
+	src  source
+	hash [32]byte
+	size uint64
+}
+
+var _ io.ReaderAt = (*OutboardReader)(nil)
+
+// NewOutboardReader returns an OutboardReader for the content and its
+// outboard encoding. The content length in the encoding is verified against
+// the root hash by verifying the last leaf.
+func NewOutboardReader(content, outboard io.ReaderAt, hash [32]byte) (*OutboardReader, error) {
+	return Tree{}.NewOutboardReader(content, outboard, hash)
+}
+
+// NewOutboardReader is like the package level NewOutboardReader but uses the
+// tree t.
+func (t Tree) NewOutboardReader(content, outboard io.ReaderAt, hash [32]byte) (*OutboardReader, error) {
+	var header [HeaderSize]byte
+	if err := readAtFull(outboard, header[:], 0); err != nil {
+		return nil, err
+	}
+	size := binary.LittleEndian.Uint64(header[:])
+
+	o := &OutboardReader{
+		src:  source{tree: t, enc: outboard, content: content},
+		hash: hash,
+		size: size,
+	}
+
+	lo := size
+	if lo > 0 {
+		lo--
+	}
+	err := o.src.walk(node{size: size, root: true}, 0, lo, size, &o.hash, &visitor{
+		leaf: func(uint64, []byte) error { return nil },
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// Size returns the size of the content.
+func (o *OutboardReader) Size() int64 {
+	return int64(o.size)
+}
+
+// ReadAt implements io.ReaderAt. It only returns content that has been
+// verified. If part of the content or encoding does not match the root
+// hash, it returns a *HashMismatchError along with the number of verified
+// bytes before it.
+func (o *OutboardReader) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	if uint64(off) >= o.size {
+		if len(p) == 0 && uint64(off) == o.size {
+			return 0, nil
+		}
+		return 0, io.EOF
+	}
+
+	lo, hi := uint64(off), o.size
+	if uint64(len(p)) < hi-lo {
+		hi = lo + uint64(len(p))
+	}
+
+	err = o.src.walk(node{size: o.size, root: true}, 0, lo, hi, &o.hash, &visitor{
+		leaf: func(start uint64, leaf []byte) error {
+			from, to := start, start+uint64(len(leaf))
+			if from < lo {
+				from = lo
+			}
+			if to > hi {
+				to = hi
+			}
+			n += copy(p[from-lo:], leaf[from-start:to-start])
+			return nil
+		},
+	})
+	if err != nil {
+		return n, err
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
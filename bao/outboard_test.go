@@ -0,0 +1,118 @@
+package bao
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/zeebo/assert"
+	"github.com/zeebo/blake3"
+)
+
+func encodeOutboard(t *testing.T, content []byte) ([]byte, [32]byte) {
+	var buf bytes.Buffer
+	hash, err := EncodeOutboard(&buf, bytes.NewReader(content), int64(len(content)))
+	assert.NoError(t, err)
+	return buf.Bytes(), hash
+}
+
+func TestEncodeOutboard(t *testing.T) {
+	for _, size := range testSizes {
+		content := testInput(size)
+		ob, hash := encodeOutboard(t, content)
+		enc, _ := encode(t, content)
+
+		assert.Equal(t, hash, blake3.Sum256(content))
+		assert.Equal(t, uint64(len(ob)), OutboardSize(uint64(size)))
+
+		// interleaving the outboard encoding with the content is the same as
+		// the combined encoding
+		var buf bytes.Buffer
+		buf.Write(ob[:HeaderSize])
+		assert.NoError(t, Tree{}.writeCombined(&buf, bytes.NewReader(content), ob[HeaderSize:], uint64(size)))
+		assert.That(t, bytes.Equal(buf.Bytes(), enc))
+	}
+}
+
+func TestOutboardReader(t *testing.T) {
+	for _, size := range testSizes {
+		content := testInput(size)
+		ob, hash := encodeOutboard(t, content)
+
+		r, err := NewOutboardReader(bytes.NewReader(content), bytes.NewReader(ob), hash)
+		assert.NoError(t, err)
+		assert.Equal(t, r.Size(), int64(size))
+
+		for off := 0; off <= size; off += 1 + size/7 {
+			for _, n := range []int{0, 1, 100, ChunkLen, 2*ChunkLen + 1, size} {
+				buf := make([]byte, n)
+				m, err := r.ReadAt(buf, int64(off))
+
+				exp := content[off:]
+				if len(exp) > n {
+					exp = exp[:n]
+				}
+				assert.Equal(t, m, len(exp))
+				assert.That(t, bytes.Equal(buf[:m], exp))
+				if m < n {
+					assert.Equal(t, err, io.EOF)
+				} else {
+					assert.NoError(t, err)
+				}
+			}
+		}
+
+		got, err := io.ReadAll(io.NewSectionReader(r, 0, r.Size()))
+		assert.NoError(t, err)
+		assert.That(t, bytes.Equal(got, content))
+	}
+}
+
+func TestOutboardReader_Tampered(t *testing.T) {
+	content := testInput(5*ChunkLen + 3)
+	ob, hash := encodeOutboard(t, content)
+
+	// a corrupt chunk only fails the reads that include it
+	bad := append([]byte(nil), content...)
+	bad[2*ChunkLen+10] ^= 1
+
+	r, err := NewOutboardReader(bytes.NewReader(bad), bytes.NewReader(ob), hash)
+	assert.NoError(t, err)
+
+	buf := make([]byte, 2*ChunkLen)
+	_, err = r.ReadAt(buf, 0)
+	assert.NoError(t, err)
+	_, err = r.ReadAt(buf, 3*ChunkLen)
+	assert.NoError(t, err)
+
+	n, err := r.ReadAt(buf, ChunkLen+5)
+	assert.Equal(t, n, ChunkLen-5)
+	assert.That(t, bytes.Equal(buf[:n], content[ChunkLen+5:2*ChunkLen]))
+
+	var mismatch *HashMismatchError
+	assert.That(t, errors.As(err, &mismatch))
+	assert.Equal(t, mismatch.Offset, uint64(2*ChunkLen))
+
+	// every corrupt parent node fails some read or the length check
+	for i := HeaderSize; i < len(ob); i++ {
+		badOb := append([]byte(nil), ob...)
+		badOb[i] ^= 1
+
+		r, err := NewOutboardReader(bytes.NewReader(content), bytes.NewReader(badOb), hash)
+		if err == nil {
+			_, err = r.ReadAt(make([]byte, len(content)), 0)
+		}
+		assert.That(t, errors.As(err, &mismatch))
+	}
+
+	// a wrong length is caught when opening the reader
+	for _, size := range []uint64{0, 4 * ChunkLen, 5 * ChunkLen, 5*ChunkLen + 2, 5*ChunkLen + 4, 6 * ChunkLen} {
+		badOb := append([]byte(nil), ob...)
+		binary.LittleEndian.PutUint64(badOb, size)
+
+		_, err := NewOutboardReader(bytes.NewReader(content), bytes.NewReader(badOb), hash)
+		assert.Error(t, err)
+	}
+}
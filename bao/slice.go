@@ -0,0 +1,68 @@
+package bao
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+)
+
+// ExtractSlice writes the slice of the combined encoding read from r that
+// covers length bytes of content from start to w. A slice is the 8 byte
+// content length followed by only the parent nodes and leaves needed to
+// verify that range, in pre-order, so its size grows with the logarithm of
+// the content length rather than the content length.
+//
+// A slice always contains at least one leaf to verify the content length:
+// the leaf at start, or the last leaf if start is past the end. The
+// encoding is not verified, so use NewSliceDecoder to read the slice.
+func ExtractSlice(w io.Writer, r io.ReaderAt, start, length uint64) error {
+	return Tree{}.ExtractSlice(w, r, start, length)
+}
+
+// ExtractSlice is like the package level ExtractSlice but uses the tree t.
+func (t Tree) ExtractSlice(w io.Writer, r io.ReaderAt, start, length uint64) error {
+	return extractSlice(w, source{tree: t, enc: r}, start, length)
+}
+
+// ExtractOutboardSlice is like ExtractSlice but reads from the content and
+// its outboard encoding. The slice is the same as the one extracted from the
+// combined encoding.
+func ExtractOutboardSlice(w io.Writer, content, outboard io.ReaderAt, start, length uint64) error {
+	return Tree{}.ExtractOutboardSlice(w, content, outboard, start, length)
+}
+
+// ExtractOutboardSlice is like the package level ExtractOutboardSlice but
+// uses the tree t.
+func (t Tree) ExtractOutboardSlice(w io.Writer, content, outboard io.ReaderAt, start, length uint64) error {
+	return extractSlice(w, source{tree: t, enc: outboard, content: content}, start, length)
+}
+
+func extractSlice(w io.Writer, src source, start, length uint64) error {
+	var header [HeaderSize]byte
+	if err := readAtFull(src.enc, header[:], 0); err != nil {
+		return err
+	}
+	size := binary.LittleEndian.Uint64(header[:])
+	lo, hi := sliceRange(size, start, length)
+
+	bw := bufio.NewWriterSize(w, 64*1024)
+	if _, err := bw.Write(header[:]); err != nil {
+		return err
+	}
+
+	err := src.walk(node{size: size, root: true}, 0, lo, hi, nil, &visitor{
+		parent: func(parent *[ParentSize]byte) error {
+			_, err := bw.Write(parent[:])
+			return err
+		},
+		leaf: func(_ uint64, leaf []byte) error {
+			_, err := bw.Write(leaf)
+			return err
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,80 @@
+package bao
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/zeebo/assert"
+	"github.com/zeebo/blake3"
+)
+
+func TestTree(t *testing.T) {
+	for _, log := range []uint8{0, 1, 2, 4} {
+		tree := Tree{ChunkGroupLog: log}
+		group := ChunkLen << log
+
+		for _, size := range append(testSizes, group-1, group, group+1, 5*group+3) {
+			content := testInput(size)
+
+			var enc, ob bytes.Buffer
+			hash, err := tree.Encode(&enc, bytes.NewReader(content), int64(size))
+			assert.NoError(t, err)
+			assert.Equal(t, hash, blake3.Sum256(content))
+			assert.Equal(t, uint64(enc.Len()), tree.EncodedSize(uint64(size)))
+
+			obHash, err := tree.EncodeOutboard(&ob, bytes.NewReader(content), int64(size))
+			assert.NoError(t, err)
+			assert.Equal(t, obHash, hash)
+			assert.Equal(t, uint64(ob.Len()), tree.OutboardSize(uint64(size)))
+
+			got, err := io.ReadAll(tree.NewDecoder(bytes.NewReader(enc.Bytes()), hash))
+			assert.NoError(t, err)
+			assert.That(t, bytes.Equal(got, content))
+
+			r, err := tree.NewOutboardReader(bytes.NewReader(content), bytes.NewReader(ob.Bytes()), hash)
+			assert.NoError(t, err)
+			got, err = io.ReadAll(io.NewSectionReader(r, 0, r.Size()))
+			assert.NoError(t, err)
+			assert.That(t, bytes.Equal(got, content))
+
+			for start := 0; start <= size; start += 1 + size/3 {
+				length := ChunkLen + 7
+
+				var s1, s2 bytes.Buffer
+				assert.NoError(t, tree.ExtractSlice(&s1, bytes.NewReader(enc.Bytes()), uint64(start), uint64(length)))
+				assert.NoError(t, tree.ExtractOutboardSlice(&s2, bytes.NewReader(content), bytes.NewReader(ob.Bytes()), uint64(start), uint64(length)))
+				assert.That(t, bytes.Equal(s1.Bytes(), s2.Bytes()))
+
+				exp := content[start:]
+				if len(exp) > length {
+					exp = exp[:length]
+				}
+				got, err := io.ReadAll(tree.NewSliceDecoder(&s1, hash, uint64(start), uint64(length)))
+				assert.NoError(t, err)
+				assert.That(t, bytes.Equal(got, exp))
+			}
+		}
+	}
+}
+
+func TestTree_Size(t *testing.T) {
+	// every doubling of the group halves the parent nodes
+	size := uint64(1 << 20)
+	assert.Equal(t, Tree{}.OutboardSize(size), uint64(HeaderSize+1023*ParentSize))
+	assert.Equal(t, Tree{ChunkGroupLog: 1}.OutboardSize(size), uint64(HeaderSize+511*ParentSize))
+	assert.Equal(t, Tree{ChunkGroupLog: 4}.OutboardSize(size), uint64(HeaderSize+63*ParentSize))
+	assert.Equal(t, Tree{ChunkGroupLog: 10}.OutboardSize(size), uint64(HeaderSize))
+}
+
+func TestTree_Mismatch(t *testing.T) {
+	// an encoding cannot be read with a different tree
+	content := testInput(16 * ChunkLen)
+
+	var enc bytes.Buffer
+	hash, err := Tree{ChunkGroupLog: 2}.Encode(&enc, bytes.NewReader(content), int64(len(content)))
+	assert.NoError(t, err)
+
+	_, err = io.ReadAll(NewDecoder(bytes.NewReader(enc.Bytes()), hash))
+	assert.Error(t, err)
+}
@@ -20,16 +20,38 @@ type hasher struct {
 	key    [8]uint32
 	stack  cvstack
 	buf    [8192]byte
+
+	// cv caches the chain of the single chunk left in buf by consumeLast
+	cv   [8]uint32
+	cvok bool
 }
 
 func (a *hasher) reset() {
 	a.len = 0
 	a.chunks = 0
+	a.cvok = false
 	a.stack.occ = 0
 	a.stack.lvls = [8]uint8{}
 	a.stack.bufn = 0
 }
 
+// copyTo copies the state into b, skipping the parts of the buffer and stack
+// that are not in use.
+func (a *hasher) copyTo(b *hasher) {
+	b.len, b.chunks, b.flags, b.key = a.len, a.chunks, a.flags, a.key
+	b.cv, b.cvok = a.cv, a.cvok
+	copy(b.buf[:a.len], a.buf[:a.len])
+
+	b.stack.occ, b.stack.lvls, b.stack.bufn = a.stack.occ, a.stack.lvls, a.stack.bufn
+	if a.stack.bufn > 0 {
+		b.stack.buf = a.stack.buf
+	}
+	for occ := a.stack.occ; occ != 0; occ &= occ - 1 {
+		l := bits.TrailingZeros64(occ) % 64
+		b.stack.stack[l] = a.stack.stack[l]
+	}
+}
+
 func (a *hasher) update(buf []byte) {
 	a.updateString(unsafe.String(unsafe.SliceData(buf), len(buf)))
 }
@@ -37,10 +59,18 @@ func (a *hasher) update(buf []byte) {
 func (a *hasher) updateString(buf string) {
 	var input *[8192]byte
 
+	if len(buf) > 0 {
+		a.settle()
+	}
+
 	for len(buf) > 0 {
-		if a.len == 0 && len(buf) > 8192 {
+		if a.len == 0 && len(buf) >= 8192 {
 			input = (*[8192]byte)(unsafe.Slice(unsafe.StringData(buf), len(buf)))
 			buf = buf[8192:]
+			if len(buf) == 0 {
+				a.consumeLast(input)
+				return
+			}
 		} else if a.len < 8192 {
 			n := copy(a.buf[a.len:], buf)
 			a.len += uint64(n)
@@ -56,6 +86,44 @@ func (a *hasher) updateString(buf string) {
 	}
 }
 
+// updateFinal is like update for input that is known to be the last. Every
+// complete chunk is hashed straight from buf, leaving only the final chunk to
+// be copied for finalize. No more input may be added afterwards.
+func (a *hasher) updateFinal(buf []byte) {
+	if a.len > 0 || a.chunks%8 != 0 || len(buf) < 8192 {
+		a.update(buf)
+		return
+	}
+
+	last := (len(buf) - 1) / consts.ChunkLen * consts.ChunkLen
+	groups := last / 8192 * 8192
+	for off := 0; off < groups; off += 8192 {
+		a.consume((*[8192]byte)(buf[off:]))
+		a.chunks += 8
+	}
+
+	if rest := (last - groups) / consts.ChunkLen; rest > 0 {
+		// there may not be a whole group left, in which case the group ending
+		// at the final chunk is hashed and the chains already pushed skipped
+		start := groups
+		if start+8192 > len(buf) {
+			start = last - 8192
+		}
+		skip := (groups - start) / consts.ChunkLen
+
+		var out, cv chainVector
+		var chain [8]uint32
+		alg.HashF((*[8192]byte)(buf[start:]), 8192, a.chunks-uint64(skip), a.flags, &a.key, &out, &chain)
+		for i := 0; i < rest; i++ {
+			copyChain(&out, skip+i, &cv, i)
+		}
+		a.stack.pushN(0, &cv, rest, a.flags, &a.key)
+		a.chunks += uint64(rest)
+	}
+
+	a.len = uint64(copy(a.buf[:], buf[last:]))
+}
+
 func (a *hasher) consume(input *[8192]byte) {
 	var out chainVector
 	var chain [8]uint32
@@ -63,6 +131,41 @@ func (a *hasher) consume(input *[8192]byte) {
 	a.stack.pushN(0, &out, 8, a.flags, &a.key)
 }
 
+// consumeLast hashes a group straight from the input when it may be the last
+// one. Finalize needs the bytes of the final chunk, so only that chunk is
+// copied into buf, and its chain is kept for when more input arrives.
+func (a *hasher) consumeLast(input *[8192]byte) {
+	var out chainVector
+	var chain [8]uint32
+	alg.HashF(input, 8192, a.chunks, a.flags, &a.key, &out, &chain)
+	a.stack.pushN(0, &out, 7, a.flags, &a.key)
+	readChain(&out, 7, &a.cv)
+	a.cvok = true
+	a.chunks += 7
+	a.len = uint64(copy(a.buf[:], input[7*consts.ChunkLen:]))
+}
+
+// settle pushes the chunk left in buf by consumeLast once more input arrives,
+// so that the input starts on a group boundary again.
+func (a *hasher) settle() {
+	if a.chunks%8 == 0 {
+		return
+	}
+	if !a.cvok {
+		var out chainVector
+		var chain [8]uint32
+		alg.HashF(&a.buf, consts.ChunkLen, a.chunks, a.flags, &a.key, &out, &chain)
+		readChain(&out, 0, &a.cv)
+	}
+
+	var cv chainVector
+	writeChain(&a.cv, &cv, 0)
+	a.stack.pushN(0, &cv, 1, a.flags, &a.key)
+	a.chunks++
+	a.len = 0
+	a.cvok = false
+}
+
 func (a *hasher) finalize(p []byte) {
 	var d Digest
 	a.finalizeDigest(&d)
@@ -77,6 +180,16 @@ func (a *hasher) finalizeDigest(d *Digest) {
 
 	d.chain = a.key
 	d.flags = a.flags | consts.Flag_ChunkEnd
+	d.counter = a.chunks
+
+	// finalizing must not change the state, and the buffered group and chunk
+	// counter must stay aligned for more input, so any changes to the stack
+	// are made to a copy
+	stack, last := &a.stack, a.buf[:a.len]
+	if a.len > consts.ChunkLen || a.stack.bufn > 0 {
+		tmp := a.stack
+		stack = &tmp
+	}
 
 	if a.len > 64 {
 		var buf chainVector
@@ -84,47 +197,46 @@ func (a *hasher) finalizeDigest(d *Digest) {
 
 		if a.len > consts.ChunkLen {
 			complete := (a.len - 1) / consts.ChunkLen
-			a.stack.pushN(0, &buf, int(complete), a.flags, &a.key)
-			a.chunks += complete
-			a.len = uint64(copy(a.buf[:], a.buf[complete*consts.ChunkLen:a.len]))
+			stack.pushN(0, &buf, int(complete), a.flags, &a.key)
+			last = last[complete*consts.ChunkLen:]
+			d.counter += complete
 		}
 	}
 
-	if a.len <= 64 {
+	if len(last) <= 64 {
 		d.flags |= consts.Flag_ChunkStart
 	}
 
-	d.counter = a.chunks
-	d.blen = uint32(a.len) % 64
+	d.blen = uint32(len(last)) % 64
 
-	base := a.len / 64 * 64
-	if a.len > 0 && d.blen == 0 {
+	base := len(last) / 64 * 64
+	if len(last) > 0 && d.blen == 0 {
 		d.blen = 64
 		base -= 64
 	}
 
 	if consts.OptimizeLittleEndian {
-		copy((*[64]byte)(unsafe.Pointer(&d.block[0]))[:], a.buf[base:a.len])
+		copy((*[64]byte)(unsafe.Pointer(&d.block[0]))[:], last[base:])
 	} else {
 		var tmp [64]byte
-		copy(tmp[:], a.buf[base:a.len])
+		copy(tmp[:], last[base:])
 		utils.BytesToWords(&tmp, &d.block)
 	}
 
-	for a.stack.bufn > 0 {
-		a.stack.flush(a.flags, &a.key)
+	for stack.bufn > 0 {
+		stack.flush(a.flags, &a.key)
 	}
 
 	var tmp [16]uint32
-	for occ := a.stack.occ; occ != 0; occ &= occ - 1 {
+	for occ := stack.occ; occ != 0; occ &= occ - 1 {
 		col := uint(bits.TrailingZeros64(occ)) % 64
 
 		alg.Compress(&d.chain, &d.block, d.counter, d.blen, d.flags, &tmp)
 
-		*(*[8]uint32)(d.block[0:8]) = a.stack.stack[col]
+		*(*[8]uint32)(d.block[0:8]) = stack.stack[col]
 		*(*[8]uint32)(d.block[8:16]) = *(*[8]uint32)(tmp[0:8])
 
-		if occ == a.stack.occ {
+		if occ == stack.occ {
 			d.chain = a.key
 			d.counter = 0
 			d.blen = consts.BlockLen
@@ -90,3 +90,79 @@ func TestHasherAlignment(t *testing.T) {
 		"981d32ed7aad9e408c5c36f6346c915ba11c2bd8b3e7d44902a11d7a141abdd9",
 		hex.EncodeToString(buf[:]))
 }
+
+func TestHasher_WriteSizes(t *testing.T) {
+	x := make([]byte, 100000)
+	for i := range x {
+		x[i] = byte(i) % 251
+	}
+
+	sum := func(h *hasher) string {
+		var buf [32]byte
+		h.finalize(buf[:])
+		return hex.EncodeToString(buf[:])
+	}
+
+	for _, n := range []int{8191, 8192, 8193, 9216, 15360, 16384, 16385, 24576, 33000, 65536, 100000} {
+		// the reference hashes through the buffer only
+		ref := hasher{key: consts.IV}
+		for i := 0; i < n; i += 1000 {
+			end := i + 1000
+			if end > n {
+				end = n
+			}
+			ref.update(x[i:end])
+		}
+		exp := sum(&ref)
+
+		h := hasher{key: consts.IV}
+		h.updateFinal(x[:n])
+		assert.Equal(t, sum(&h), exp)
+
+		for _, size := range []int{8192, 16384, 32 * 1024} {
+			for _, first := range []int{0, 1, 8192} {
+				if first > n {
+					continue
+				}
+
+				h := hasher{key: consts.IV}
+				h.update(x[:first])
+				for i := first; i < n; i += size {
+					end := i + size
+					if end > n {
+						end = n
+					}
+					h.update(x[i:end])
+
+					// finalizing in between does not change the state
+					part := Sum256(x[:end])
+					assert.Equal(t, sum(&h), hex.EncodeToString(part[:]))
+				}
+				assert.Equal(t, sum(&h), exp)
+			}
+		}
+	}
+}
+
+func TestHasher_FinalizeKeepsState(t *testing.T) {
+	x := make([]byte, 1<<20+100)
+	for i := range x {
+		x[i] = byte(i) % 251
+	}
+	exp := Sum256(x)
+
+	for _, n := range []int{1, 1025, 5000, 8192, 8193, 100000} {
+		// finalizing must leave the state aligned for parallel writes
+		h := New()
+		_, _ = h.Write(x[:n])
+		_ = h.Sum(nil)
+		_, _ = h.WriteParallel(x[n:], 4)
+		assert.Equal(t, hex.EncodeToString(h.Sum(nil)), hex.EncodeToString(exp[:]))
+
+		h = New()
+		_, _ = h.Write(x[:n])
+		_ = h.Sum(nil)
+		_, _ = h.Write(x[n:])
+		assert.Equal(t, hex.EncodeToString(h.Sum(nil)), hex.EncodeToString(exp[:]))
+	}
+}
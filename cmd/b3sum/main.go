@@ -0,0 +1,350 @@
+// Command b3sum prints or checks BLAKE3 checksums. Its flags and output match
+// the b3sum command of the BLAKE3 reference implementation, so scripts written
+// for one work with the other. Checksum files are read and written with the
+// sumfile package.
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/zeebo/blake3"
+	"github.com/zeebo/blake3/sumfile"
+)
+
+// mmapMin is the smallest file that is hashed with multiple goroutines, the
+// same threshold the reference implementation uses for memory mapping.
+const mmapMin = 16 * 1024
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+type options struct {
+	keyed     bool
+	deriveKey string
+	length    uint64
+	seek      uint64
+	threads   int
+	noMmap    bool
+	noNames   bool
+	raw       bool
+	tag       bool
+	check     bool
+	quiet     bool
+}
+
+// run runs b3sum with the given arguments and returns the exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var o options
+
+	set := flag.NewFlagSet("b3sum", flag.ContinueOnError)
+	set.SetOutput(stderr)
+	set.BoolVar(&o.keyed, "keyed", false, "use the keyed mode, reading the 32-byte key from stdin")
+	set.StringVar(&o.deriveKey, "derive-key", "", "use the key derivation mode, with the given context string")
+	set.Uint64Var(&o.length, "length", 32, "the number of output bytes, before hex encoding")
+	set.Uint64Var(&o.length, "l", 32, "shorthand for --length")
+	set.Uint64Var(&o.seek, "seek", 0, "the starting output byte offset, before hex encoding")
+	set.IntVar(&o.threads, "num-threads", 0, "the maximum number of threads to use, or 0 for all cores")
+	set.BoolVar(&o.noMmap, "no-mmap", false, "disable memory mapping and multithreading")
+	set.BoolVar(&o.noNames, "no-names", false, "omit filenames in the output")
+	set.BoolVar(&o.raw, "raw", false, "write raw output bytes to stdout, rather than hex")
+	set.BoolVar(&o.tag, "tag", false, "output BSD-style checksums: BLAKE3 ([FILE]) = [HASH]")
+	set.BoolVar(&o.check, "check", false, "read BLAKE3 sums from the [FILE]s and check them")
+	set.BoolVar(&o.check, "c", false, "shorthand for --check")
+	set.BoolVar(&o.quiet, "quiet", false, "skip printing OK for each checked file")
+	set.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: b3sum [OPTIONS] [FILE]...\n\nOptions:\n")
+		set.PrintDefaults()
+	}
+
+	flags, files := splitArgs(set, args)
+	if err := set.Parse(flags); errors.Is(err, flag.ErrHelp) {
+		return 0
+	} else if err != nil {
+		return 2
+	}
+
+	given := make(map[string]bool)
+	set.Visit(func(f *flag.Flag) { given[f.Name] = true })
+	deriving := given["derive-key"]
+
+	switch {
+	case o.check && (o.raw || o.tag || o.noNames || given["length"] || given["l"] || given["seek"]):
+		return usage(stderr, "--check cannot be used with --raw, --tag, --no-names, --length or --seek")
+	case o.keyed && deriving:
+		return usage(stderr, "--keyed cannot be used with --derive-key")
+	case o.raw && len(files) > 1:
+		return usage(stderr, "Only one filename can be provided when using --raw")
+	case o.seek > math.MaxInt64:
+		return usage(stderr, "--seek is too large")
+	}
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	for _, path := range files {
+		if o.keyed && path == "-" {
+			return fail(stderr, errors.New("Cannot open `-` in keyed mode"))
+		}
+	}
+
+	var base *blake3.Hasher
+	switch {
+	case o.keyed:
+		key, err := readKey(stdin)
+		if err != nil {
+			return fail(stderr, err)
+		}
+		base, _ = blake3.NewKeyed(key)
+	case deriving:
+		base = blake3.NewDeriveKey(o.deriveKey)
+	default:
+		base = blake3.New()
+	}
+
+	out := bufio.NewWriter(stdout)
+	defer func() { _ = out.Flush() }()
+
+	if o.check {
+		return o.checkFiles(base, files, stdin, out, stderr)
+	}
+
+	code := 0
+	for _, path := range files {
+		d, err := hashPath(&o, base.Clone(), path, stdin)
+		if err == nil {
+			err = o.write(out, d, path)
+		}
+		if err == nil {
+			err = out.Flush()
+		}
+		if err != nil {
+			_ = out.Flush()
+			fmt.Fprintf(stderr, "b3sum: %s: %v\n", path, unwrapPath(err))
+			code = 1
+		}
+	}
+	return code
+}
+
+// splitArgs separates the flags, along with their values, from the files, so
+// that flags may follow files like they can for the reference implementation.
+func splitArgs(set *flag.FlagSet, args []string) (flags, files []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return flags, append(files, args[i+1:]...)
+		case arg == "-" || !strings.HasPrefix(arg, "-"):
+			files = append(files, arg)
+			continue
+		}
+
+		flags = append(flags, arg)
+		name := strings.TrimLeft(arg, "-")
+		if strings.Contains(name, "=") {
+			continue
+		}
+		if f := set.Lookup(name); f != nil && !isBool(f) && i+1 < len(args) {
+			flags = append(flags, args[i+1])
+			i++
+		}
+	}
+	return flags, files
+}
+
+func isBool(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// readKey reads exactly 32 key bytes from r.
+func readKey(r io.Reader) ([]byte, error) {
+	key, err := io.ReadAll(io.LimitReader(r, 33))
+	switch {
+	case err != nil:
+		return nil, err
+	case len(key) < 32:
+		return nil, fmt.Errorf("expected 32 key bytes from stdin, found %d", len(key))
+	case len(key) > 32:
+		return nil, errors.New("read more than 32 key bytes from stdin")
+	}
+	return key, nil
+}
+
+// hashPath writes the contents of the file at path, or stdin for "-", into h
+// and returns the resulting Digest.
+func hashPath(o *options, h *blake3.Hasher, path string, stdin io.Reader) (*blake3.Digest, error) {
+	if path == "-" {
+		if o.noMmap {
+			stdin = struct{ io.Reader }{stdin}
+		}
+		if _, err := h.ReadFrom(stdin); err != nil {
+			return nil, err
+		}
+		return h.Digest(), nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case o.noMmap:
+		// hiding the *os.File keeps ReadFrom from memory mapping it
+		_, err = h.ReadFrom(struct{ io.Reader }{f})
+	case o.threads != 1 && fi.Mode().IsRegular() && fi.Size() >= mmapMin:
+		_, err = h.WriteReaderAt(f, fi.Size(), o.threads)
+	default:
+		_, err = h.ReadFrom(f)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return h.Digest(), nil
+}
+
+// write writes the output line for the file at path.
+func (o *options) write(w *bufio.Writer, d *blake3.Digest, path string) error {
+	if _, err := d.Seek(int64(o.seek), io.SeekStart); err != nil {
+		return err
+	}
+
+	// the length is chosen by the user, so the output is streamed rather
+	// than read into memory first
+	output := io.LimitReader(d, int64(o.length))
+	if o.length > math.MaxInt64 {
+		output = d
+	}
+	if o.raw {
+		_, err := io.Copy(w, output)
+		return err
+	}
+
+	name, escaped := sumfile.EscapeName(path)
+	if escaped && !o.noNames {
+		_ = w.WriteByte('\\')
+	}
+	if o.tag && !o.noNames {
+		_, _ = w.WriteString("BLAKE3 (" + name + ") = ")
+	}
+	if _, err := io.Copy(hex.NewEncoder(w), output); err != nil {
+		return err
+	}
+	if !o.tag && !o.noNames {
+		_, _ = w.WriteString("  " + name)
+	}
+	return w.WriteByte('\n')
+}
+
+// checkFiles verifies every entry of the checksum files at paths, printing
+// the result for each and a summary of the failures like sha256sum -c.
+func (o *options) checkFiles(base *blake3.Hasher, paths []string, stdin io.Reader, w *bufio.Writer, stderr io.Writer) int {
+	var malformed, unread, mismatched int
+	code := 0
+
+	for _, path := range paths {
+		r := stdin
+		if path != "-" {
+			f, err := os.Open(path)
+			if err != nil {
+				fmt.Fprintf(stderr, "b3sum: %s: %v\n", path, unwrapPath(err))
+				code = 1
+				continue
+			}
+			defer func() { _ = f.Close() }()
+			r = f
+		}
+
+		entries := 0
+		sr := sumfile.NewReader(r)
+		for {
+			e, err := sr.Next()
+			var se *sumfile.SyntaxError
+			if errors.Is(err, io.EOF) {
+				break
+			} else if errors.As(err, &se) {
+				fmt.Fprintf(stderr, "b3sum: %s: %v\n", path, err)
+				malformed++
+				continue
+			} else if err != nil {
+				fmt.Fprintf(stderr, "b3sum: %s: %v\n", path, err)
+				code = 1
+				break
+			}
+			entries++
+
+			name, escaped := sumfile.EscapeName(e.Name)
+			if escaped {
+				name = "\\" + name
+			}
+
+			d, err := hashPath(o, base.Clone(), e.Name, stdin)
+			switch {
+			case err != nil:
+				fmt.Fprintf(w, "%s: FAILED (%v)\n", name, unwrapPath(err))
+				unread++
+			case !e.Verify(d):
+				fmt.Fprintf(w, "%s: FAILED\n", name)
+				mismatched++
+			case !o.quiet:
+				fmt.Fprintf(w, "%s: OK\n", name)
+			}
+			_ = w.Flush()
+		}
+
+		if entries == 0 {
+			fmt.Fprintf(stderr, "b3sum: %s: no properly formatted checksum lines found\n", path)
+			code = 1
+		}
+	}
+
+	warn := func(n int, one, many string) {
+		if n == 1 {
+			fmt.Fprintf(stderr, "b3sum: WARNING: 1 %s\n", one)
+		} else if n > 1 {
+			fmt.Fprintf(stderr, "b3sum: WARNING: %d %s\n", n, many)
+		}
+	}
+	warn(malformed, "line is improperly formatted", "lines are improperly formatted")
+	warn(unread, "listed file could not be read", "listed files could not be read")
+	warn(mismatched, "computed checksum did NOT match", "computed checksums did NOT match")
+
+	if malformed+unread+mismatched > 0 {
+		code = 1
+	}
+	return code
+}
+
+func unwrapPath(err error) error {
+	var pe *fs.PathError
+	if errors.As(err, &pe) {
+		return pe.Err
+	}
+	return err
+}
+
+func usage(w io.Writer, msg string) int {
+	fmt.Fprintf(w, "b3sum: %s\n", msg)
+	return 2
+}
+
+func fail(w io.Writer, err error) int {
+	fmt.Fprintf(w, "b3sum: %v\n", err)
+	return 1
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zeebo/assert"
+	"github.com/zeebo/blake3"
+)
+
+func b3sum(t *testing.T, stdin string, args ...string) (code int, stdout, stderr string) {
+	t.Helper()
+	var out, errs bytes.Buffer
+	code = run(args, strings.NewReader(stdin), &out, &errs)
+	return code, out.String(), errs.String()
+}
+
+func TestB3sum(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+		return path
+	}
+
+	const sum = "b224a1da2bf5e72b337dc6dde457a05265a06dec8875be379e2ad2be5edb3bf2"
+	a := write("a", "some data")
+
+	t.Run("Basic", func(t *testing.T) {
+		code, out, _ := b3sum(t, "", a)
+		assert.Equal(t, code, 0)
+		assert.Equal(t, out, sum+"  "+a+"\n")
+
+		code, out, _ = b3sum(t, "some data")
+		assert.Equal(t, code, 0)
+		assert.Equal(t, out, sum+"  -\n")
+	})
+
+	t.Run("Flags", func(t *testing.T) {
+		_, out, _ := b3sum(t, "", a, "--no-names")
+		assert.Equal(t, out, sum+"\n")
+
+		_, out, _ = b3sum(t, "", "--tag", a)
+		assert.Equal(t, out, "BLAKE3 ("+a+") = "+sum+"\n")
+
+		_, out, _ = b3sum(t, "", "-l", "4", "--seek=2", "--no-names", a)
+		assert.Equal(t, out, sum[4:12]+"\n")
+
+		_, out, _ = b3sum(t, "", "--raw", "--length", "3", a)
+		assert.Equal(t, hex.EncodeToString([]byte(out)), sum[:6])
+
+		_, out, _ = b3sum(t, "", "--no-names", "--length", "100", a)
+		d := blake3.New()
+		_, _ = d.WriteString("some data")
+		exp := make([]byte, 100)
+		_, _ = d.Digest().Read(exp)
+		assert.Equal(t, out, hex.EncodeToString(exp)+"\n")
+	})
+
+	t.Run("Keyed", func(t *testing.T) {
+		_, out, _ := b3sum(t, strings.Repeat("1", 32), "--keyed", "--no-names", a)
+		assert.Equal(t, out, "107c6f88638356d73cdb80f4d56ffe50abcbd9664a80c8ab2b83b1f946ebaba1\n")
+
+		code, _, errs := b3sum(t, strings.Repeat("1", 31), "--keyed", a)
+		assert.Equal(t, code, 1)
+		assert.That(t, strings.Contains(errs, "found 31"))
+
+		code, _, _ = b3sum(t, strings.Repeat("1", 33), "--keyed", a)
+		assert.Equal(t, code, 1)
+
+		code, _, _ = b3sum(t, strings.Repeat("1", 32), "--keyed")
+		assert.Equal(t, code, 1)
+	})
+
+	t.Run("DeriveKey", func(t *testing.T) {
+		m := write("m", "some material to derive key from")
+		_, out, _ := b3sum(t, "", "--derive-key", "my-application v0.1.1 session tokens v1", "--no-names", m)
+		assert.Equal(t, out, "98a3333af735f89eb301b56eaf6a77713aa03cdb0057e5b04352a63ea9204add\n")
+
+		code, _, _ := b3sum(t, "", "--derive-key", "ctx", "--keyed", m)
+		assert.Equal(t, code, 2)
+	})
+
+	t.Run("Escaping", func(t *testing.T) {
+		b := write("b\\c", "some data")
+		_, out, _ := b3sum(t, "", b)
+		assert.Equal(t, out, "\\"+sum+"  "+strings.ReplaceAll(b, "\\", "\\\\")+"\n")
+	})
+
+	t.Run("Check", func(t *testing.T) {
+		b := write("b\\c", "other data")
+		_, sums, _ := b3sum(t, "", a, b)
+		_, tags, _ := b3sum(t, "", "--tag", a)
+		sums += tags
+		list := write("list", sums)
+
+		code, out, errs := b3sum(t, "", "--check", list)
+		assert.Equal(t, code, 0)
+		assert.Equal(t, out, a+": OK\n\\"+strings.ReplaceAll(b, "\\", "\\\\")+": OK\n"+a+": OK\n")
+		assert.Equal(t, errs, "")
+
+		code, out, _ = b3sum(t, sums, "-c", "--quiet")
+		assert.Equal(t, code, 0)
+		assert.Equal(t, out, "")
+
+		bad := write("bad", "garbage\n"+strings.Repeat("0", 64)+"  "+a+"\n"+sum+"  "+filepath.Join(dir, "missing")+"\n"+sum+"  "+a+"\n")
+		code, out, errs = b3sum(t, "", "-c", bad)
+		assert.Equal(t, code, 1)
+		assert.Equal(t, strings.Count(out, ": FAILED"), 2)
+		assert.That(t, strings.HasSuffix(out, a+": OK\n"))
+		assert.That(t, strings.Contains(errs, "b3sum: "+bad+": line 1: invalid separator\n"))
+		assert.That(t, strings.Contains(errs, "WARNING: 1 line is improperly formatted\n"))
+		assert.That(t, strings.Contains(errs, "WARNING: 1 listed file could not be read\n"))
+		assert.That(t, strings.Contains(errs, "WARNING: 1 computed checksum did NOT match\n"))
+
+		// a short hash would only check a prefix of the output
+		short := write("short", sum[:2]+"  "+a+"\n"+sum[:62]+"  "+a+"\n")
+		code, out, errs = b3sum(t, "", "--check", short)
+		assert.Equal(t, code, 1)
+		assert.Equal(t, out, "")
+		assert.That(t, strings.Contains(errs, "line 1: invalid hash length\n"))
+		assert.That(t, strings.Contains(errs, "WARNING: 2 lines are improperly formatted\n"))
+		assert.That(t, strings.Contains(errs, "no properly formatted checksum lines found"))
+
+		code, _, errs = b3sum(t, "", "-c", write("empty", "\n"))
+		assert.Equal(t, code, 1)
+		assert.That(t, strings.Contains(errs, "no properly formatted checksum lines found"))
+
+		code, _, _ = b3sum(t, "", "-c", "--tag", list)
+		assert.Equal(t, code, 2)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		code, out, errs := b3sum(t, "", filepath.Join(dir, "missing"), a)
+		assert.Equal(t, code, 1)
+		assert.Equal(t, out, sum+"  "+a+"\n")
+		assert.That(t, strings.HasPrefix(errs, "b3sum: "+filepath.Join(dir, "missing")+": "))
+
+		code, _, _ = b3sum(t, "", "--raw", a, a)
+		assert.Equal(t, code, 2)
+
+		code, _, _ = b3sum(t, "", "--bogus", a)
+		assert.Equal(t, code, 2)
+	})
+
+	t.Run("Large", func(t *testing.T) {
+		data := make([]byte, 1<<20+17)
+		for i := range data {
+			data[i] = byte(i) % 251
+		}
+		big := write("big", string(data))
+		exp := blake3.Sum256(data)
+		line := hex.EncodeToString(exp[:]) + "\n"
+
+		for _, args := range [][]string{
+			{"--no-names", big},
+			{"--no-names", "--num-threads", "1", big},
+			{"--no-names", "--num-threads", "3", big},
+			{"--no-names", "--no-mmap", big},
+		} {
+			_, out, _ := b3sum(t, "", args...)
+			assert.Equal(t, out, line)
+		}
+
+		_, out, _ := b3sum(t, string(data), "--no-names", "--no-mmap")
+		assert.Equal(t, out, line)
+	})
+
+	t.Run("LongOutput", func(t *testing.T) {
+		// the output is streamed, so a huge length writes until stdout fails
+		// instead of allocating it all up front
+		for _, length := range []string{"18446744073709551615", "1000000000000"} {
+			w := &limitWriter{n: 1 << 20}
+			code := run([]string{"--length", length, a}, strings.NewReader(""), w, new(bytes.Buffer))
+			assert.Equal(t, code, 1)
+			assert.Equal(t, w.written, 1<<20)
+			assert.That(t, strings.HasPrefix(w.buf.String(), sum))
+		}
+
+		_, out, _ := b3sum(t, "", "--tag", "-l", "1000", a)
+		full := make([]byte, 1000)
+		d := blake3.New()
+		_, _ = d.WriteString("some data")
+		_, _ = d.Digest().Read(full)
+		assert.Equal(t, out, "BLAKE3 ("+a+") = "+hex.EncodeToString(full)+"\n")
+	})
+}
+
+// limitWriter accepts n bytes and then fails.
+type limitWriter struct {
+	buf     bytes.Buffer
+	n       int
+	written int
+}
+
+func (w *limitWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n-w.written {
+		p = p[:w.n-w.written]
+	}
+	w.written += len(p)
+	w.buf.Write(p)
+	if w.written == w.n {
+		return len(p), errors.New("disk full")
+	}
+	return len(p), nil
+}
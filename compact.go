@@ -0,0 +1,266 @@
+package blake3
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/zeebo/blake3/internal/alg"
+	"github.com/zeebo/blake3/internal/consts"
+	"github.com/zeebo/blake3/internal/utils"
+)
+
+// CompactHasher is a hash.Hash for BLAKE3 that uses a small fraction of the
+// memory of a Hasher. Instead of buffering a group of chunks it buffers a
+// single 64 byte block, and its stack only holds the occupied levels, so a
+// CompactHasher that has hashed a gigabyte is still well under a kilobyte.
+// Small writes are slower than with a Hasher, but the output is identical.
+type CompactHasher struct {
+	size   int
+	flags  uint32
+	key    [8]uint32
+	chunks uint64    // number of complete chunks
+	chain  [8]uint32 // chain of the current chunk
+	blocks int       // number of blocks of the current chunk in chain
+	buf    [consts.BlockLen]byte
+	bufn   int
+	stack  [][8]uint32 // chains of complete subtrees, largest first
+}
+
+// NewCompact returns a new CompactHasher that has a digest size of 32 bytes.
+//
+// If you need more or less output bytes than that, use the Digest method.
+func NewCompact() *CompactHasher {
+	return &CompactHasher{
+		size:  32,
+		key:   consts.IV,
+		chain: consts.IV,
+	}
+}
+
+// NewCompactKeyed is like NewKeyed but returns a CompactHasher.
+func NewCompactKeyed(key []byte) (*CompactHasher, error) {
+	if len(key) != 32 {
+		return nil, errors.New("invalid key size")
+	}
+
+	c := &CompactHasher{
+		size:  32,
+		flags: consts.Flag_Keyed,
+	}
+	utils.KeyFromBytes(key, &c.key)
+	c.chain = c.key
+
+	return c, nil
+}
+
+// NewCompactDeriveKey is like NewDeriveKey but returns a CompactHasher.
+func NewCompactDeriveKey(context string) *CompactHasher {
+	c := &CompactHasher{
+		size:  32,
+		flags: consts.Flag_DeriveKeyContext,
+		key:   consts.IV,
+		chain: consts.IV,
+	}
+
+	var buf [32]byte
+	_, _ = c.WriteString(context)
+	_, _ = c.Digest().Read(buf[:])
+
+	utils.KeyFromBytes(buf[:], &c.key)
+	c.flags = consts.Flag_DeriveKeyMaterial
+	c.Reset()
+
+	return c
+}
+
+// Write implements part of the hash.Hash interface. It never returns an error.
+func (c *CompactHasher) Write(p []byte) (int, error) {
+	c.updateString(unsafe.String(unsafe.SliceData(p), len(p)))
+	return len(p), nil
+}
+
+// WriteString is like Write but specialized to strings to avoid allocations.
+func (c *CompactHasher) WriteString(p string) (int, error) {
+	c.updateString(p)
+	return len(p), nil
+}
+
+// Reset implements part of the hash.Hash interface. It causes the
+// CompactHasher to act as if it was newly created.
+func (c *CompactHasher) Reset() {
+	c.chunks = 0
+	c.chain = c.key
+	c.blocks = 0
+	c.bufn = 0
+	c.stack = c.stack[:0]
+}
+
+// Clone returns a new CompactHasher with the same internal state.
+//
+// Modifying the resulting CompactHasher will not modify the original, and
+// vice versa.
+func (c *CompactHasher) Clone() *CompactHasher {
+	n := *c
+	n.stack = append([][8]uint32(nil), c.stack...)
+	return &n
+}
+
+// Size implements part of the hash.Hash interface. It returns the number of
+// bytes the hash will output in Sum.
+func (c *CompactHasher) Size() int {
+	return c.size
+}
+
+// BlockSize implements part of the hash.Hash interface. It returns the most
+// natural size to write to the CompactHasher.
+func (c *CompactHasher) BlockSize() int {
+	return 64
+}
+
+// Sum implements part of the hash.Hash interface. It appends the digest of
+// the CompactHasher to the provided buffer and returns it.
+func (c *CompactHasher) Sum(b []byte) []byte {
+	var d Digest
+	c.finalizeDigest(&d)
+
+	if top := len(b) + c.size; top <= cap(b) && top >= len(b) {
+		_, _ = d.Read(b[len(b):top])
+		return b[:top]
+	}
+
+	tmp := make([]byte, c.size)
+	_, _ = d.Read(tmp)
+	return append(b, tmp...)
+}
+
+// Digest takes a snapshot of the hash state and returns an object that can
+// be used to read and seek through 2^64 bytes of digest output.
+func (c *CompactHasher) Digest() *Digest {
+	var d Digest
+	c.finalizeDigest(&d)
+	return &d
+}
+
+func (c *CompactHasher) updateString(p string) {
+	for len(p) > 0 {
+		// the buffered block is only compressed once more input arrives
+		// because the last block of the input is finalized differently
+		if c.bufn == consts.BlockLen {
+			c.compressBlock(&c.buf)
+			c.bufn = 0
+		}
+
+		// the last chunk of the input must not be completed, so a group that
+		// ends the input only hashes its first seven chunks
+		if c.bufn == 0 && c.blocks == 0 && len(p) >= 8192 {
+			n := 8
+			if len(p) == 8192 {
+				n = 7
+			}
+			c.compressChunks((*[8192]byte)(unsafe.Slice(unsafe.StringData(p), len(p))), n)
+			p = p[n*consts.ChunkLen:]
+			continue
+		}
+
+		if c.bufn == 0 && len(p) > consts.BlockLen {
+			c.compressBlock((*[consts.BlockLen]byte)(unsafe.Slice(unsafe.StringData(p), len(p))))
+			p = p[consts.BlockLen:]
+			continue
+		}
+
+		n := copy(c.buf[c.bufn:], p)
+		c.bufn += n
+		p = p[n:]
+	}
+}
+
+// compressChunks hashes the first n chunks of input, which are followed by
+// more input.
+func (c *CompactHasher) compressChunks(input *[8192]byte, n int) {
+	var out chainVector
+	var chain [8]uint32
+	alg.HashF(input, uint64(n)*consts.ChunkLen, c.chunks, c.flags, &c.key, &out, &chain)
+	for i := 0; i < n; i++ {
+		readChain(&out, i, &chain)
+		c.push(&chain)
+	}
+}
+
+// compressBlock compresses a block of the current chunk that is followed by
+// more input, pushing the chunk once it is complete.
+func (c *CompactHasher) compressBlock(input *[consts.BlockLen]byte) {
+	flags := c.flags
+	if c.blocks == 0 {
+		flags |= consts.Flag_ChunkStart
+	}
+	if c.blocks == 15 {
+		flags |= consts.Flag_ChunkEnd
+	}
+
+	var block *[16]uint32
+	var tmp [16]uint32
+	if consts.OptimizeLittleEndian {
+		block = (*[16]uint32)(unsafe.Pointer(input))
+	} else {
+		block = &tmp
+		utils.BytesToWords(input, block)
+	}
+
+	var out [16]uint32
+	alg.Compress(&c.chain, block, c.chunks, consts.BlockLen, flags, &out)
+	c.chain = *(*[8]uint32)(out[0:8])
+	c.blocks++
+
+	if c.blocks == 16 {
+		c.push(&c.chain)
+		c.chain = c.key
+		c.blocks = 0
+	}
+}
+
+// push adds the chain of a complete chunk that is followed by more input,
+// merging every subtree it completes. None of them can be the root.
+func (c *CompactHasher) push(cv *[8]uint32) {
+	var block, out [16]uint32
+
+	chain := *cv
+	c.chunks++
+	for total := c.chunks; total&1 == 0; total >>= 1 {
+		top := len(c.stack) - 1
+		*(*[8]uint32)(block[0:8]) = c.stack[top]
+		*(*[8]uint32)(block[8:16]) = chain
+		alg.Compress(&c.key, &block, 0, consts.BlockLen, c.flags|consts.Flag_Parent, &out)
+		chain = *(*[8]uint32)(out[0:8])
+		c.stack = c.stack[:top]
+	}
+	c.stack = append(c.stack, chain)
+}
+
+func (c *CompactHasher) finalizeDigest(d *Digest) {
+	d.chain = c.chain
+	d.counter = c.chunks
+	d.blen = uint32(c.bufn)
+	d.flags = c.flags | consts.Flag_ChunkEnd
+	if c.blocks == 0 {
+		d.flags |= consts.Flag_ChunkStart
+	}
+
+	var tmp [consts.BlockLen]byte
+	copy(tmp[:], c.buf[:c.bufn])
+	utils.BytesToWords(&tmp, &d.block)
+
+	var out [16]uint32
+	for i := len(c.stack) - 1; i >= 0; i-- {
+		alg.Compress(&d.chain, &d.block, d.counter, d.blen, d.flags, &out)
+
+		*(*[8]uint32)(d.block[0:8]) = c.stack[i]
+		*(*[8]uint32)(d.block[8:16]) = *(*[8]uint32)(out[0:8])
+
+		d.chain = c.key
+		d.counter = 0
+		d.blen = consts.BlockLen
+		d.flags = c.flags | consts.Flag_Parent
+	}
+
+	d.flags |= consts.Flag_Root
+}
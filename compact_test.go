@@ -0,0 +1,89 @@
+package blake3
+
+import (
+	"encoding/hex"
+	"testing"
+	"unsafe"
+
+	"github.com/zeebo/assert"
+)
+
+func TestCompactHasher_Vectors(t *testing.T) {
+	check := func(t *testing.T, c *CompactHasher, input []byte, hash string) {
+		// write in uneven pieces so that every path is taken
+		for i, n := 0, 1; i < len(input); i, n = i+n, n*3%10000+1 {
+			end := i + n
+			if end > len(input) {
+				end = len(input)
+			}
+			_, _ = c.Write(input[i:end])
+		}
+
+		buf := make([]byte, len(hash)/2)
+		_, _ = c.Digest().Read(buf)
+		assert.Equal(t, hash, hex.EncodeToString(buf))
+
+		// one more reset, full write, full read
+		c.Reset()
+		_, _ = c.Write(input)
+		_, _ = c.Digest().Read(buf)
+		assert.Equal(t, hash, hex.EncodeToString(buf))
+	}
+
+	for _, tv := range vectors {
+		check(t, NewCompact(), tv.input(), tv.hash)
+
+		c, err := NewCompactKeyed([]byte(testVectorKey))
+		assert.NoError(t, err)
+		check(t, c, tv.input(), tv.keyedHash)
+
+		check(t, NewCompactDeriveKey(testVectorContext), tv.input(), tv.deriveKey)
+	}
+}
+
+func TestCompactHasher(t *testing.T) {
+	x := make([]byte, 1<<18+100)
+	for i := range x {
+		x[i] = byte(i) % 251
+	}
+
+	for _, n := range []int{0, 1, 64, 65, 1024, 1025, 8192, 8193, 16384, 100000, len(x)} {
+		h := New()
+		_, _ = h.Write(x[:n])
+
+		c := NewCompact()
+		_, _ = c.Write(x[:n])
+		assert.Equal(t, hex.EncodeToString(c.Sum(nil)), hex.EncodeToString(h.Sum(nil)))
+
+		// clones are independent
+		d := c.Clone()
+		_, _ = d.Write(x[:n])
+		_, _ = h.Write(x[:n])
+		assert.Equal(t, hex.EncodeToString(d.Sum(nil)), hex.EncodeToString(h.Sum(nil)))
+		sum := Sum256(x[:n])
+		assert.Equal(t, hex.EncodeToString(c.Sum(nil)), hex.EncodeToString(sum[:]))
+	}
+
+	_, err := NewCompactKeyed(make([]byte, 31))
+	assert.Error(t, err)
+}
+
+func TestCompactHasher_Size(t *testing.T) {
+	c := NewCompact()
+	_, _ = c.Write(make([]byte, 1<<24+1))
+
+	// 16 MiB is a single subtree, so only one level is kept for it
+	assert.Equal(t, len(c.stack), 1)
+	assert.That(t, unsafe.Sizeof(*c) < 256)
+}
+
+func BenchmarkCompactHasher(b *testing.B) {
+	buf := make([]byte, 32*1024)
+	b.SetBytes(int64(len(buf)))
+	b.ReportAllocs()
+
+	c := NewCompact()
+	for i := 0; i < b.N; i++ {
+		_, _ = c.Write(buf)
+	}
+}
@@ -1,15 +1,33 @@
 package blake3
 
 import (
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"unsafe"
 
 	"github.com/zeebo/blake3/internal/alg"
 	"github.com/zeebo/blake3/internal/consts"
+	"github.com/zeebo/blake3/internal/digest"
 	"github.com/zeebo/blake3/internal/utils"
 )
 
+const (
+	digestMagic = "b3d\x01"
+
+	// digestMarshaledSize is the size of a marshaled digest: the magic,
+	// chain, block, block length, flags and position.
+	digestMarshaledSize = len(digestMagic) + 32 + 64 + 4 + 4 + 8
+)
+
+func init() {
+	digest.New = func(chain *[8]uint32, block *[16]uint32, blen, flags uint32) interface{} {
+		return &Digest{chain: *chain, block: *block, blen: blen, flags: flags | consts.Flag_Root}
+	}
+}
+
 // Digest captures the state of a Hasher allowing reading and seeking through
 // the output stream.
 type Digest struct {
@@ -33,6 +51,13 @@ func (d *Digest) Read(p []byte) (n int, err error) {
 		d.bufn -= n
 	}
 
+	// output blocks are independent, so large reads compute 16 at once
+	for len(p) >= 16*consts.BlockLen {
+		alg.HashX(&d.chain, &d.block, d.counter, d.blen, d.flags, (*[16 * consts.BlockLen]byte)(p))
+		d.counter += 16
+		p = p[16*consts.BlockLen:]
+	}
+
 	for len(p) >= 64 {
 		d.fillBuf()
 
@@ -56,6 +81,57 @@ func (d *Digest) Read(p []byte) (n int, err error) {
 	return n, nil
 }
 
+// ReadAt implements io.ReaderAt. It reads output starting at offset off into
+// p without changing the position of the Digest, so it is safe to call from
+// multiple goroutines concurrently with other calls to ReadAt. Like Read, it
+// always fills the entire buffer.
+func (d *Digest) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, fmt.Errorf("read before start")
+	}
+
+	tmp := Digest{chain: d.chain, block: d.block, blen: d.blen, flags: d.flags}
+	tmp.setPosition(uint64(off))
+	return tmp.Read(p)
+}
+
+// WriteTo implements io.WriterTo. The output stream never ends, so it writes
+// to w until w returns an error, and returns that error along with the number
+// of bytes written. The position of the Digest is left just after the last
+// byte that was written.
+func (d *Digest) WriteTo(w io.Writer) (n int64, err error) {
+	return d.writeTo(w, math.MaxUint64)
+}
+
+// writeTo writes at most limit bytes of output to w, stopping early only if
+// w returns an error.
+func (d *Digest) writeTo(w io.Writer, limit uint64) (n int64, err error) {
+	buf := make([]byte, 32*1024)
+	pos := d.position()
+
+	// only the first read starts inside of a block, so the rest are computed
+	// directly into buf
+	chunk := buf[:len(buf)-int(pos%consts.BlockLen)]
+	for rem := limit; rem > 0; {
+		if uint64(len(chunk)) > rem {
+			chunk = chunk[:rem]
+		}
+		_, _ = d.Read(chunk)
+		m, err := w.Write(chunk)
+		n += int64(m)
+		if err == nil && m < len(chunk) {
+			err = io.ErrShortWrite
+		}
+		if err != nil {
+			d.setPosition(pos + uint64(n))
+			return n, err
+		}
+		rem -= uint64(len(chunk))
+		chunk = buf
+	}
+	return n, nil
+}
+
 // Seek sets the position to the provided location. Only SeekStart and
 // SeekCurrent are allowed.
 func (d *Digest) Seek(offset int64, whence int) (int64, error) {
@@ -64,7 +140,7 @@ func (d *Digest) Seek(offset int64, whence int) (int64, error) {
 	case io.SeekEnd:
 		return 0, fmt.Errorf("seek from end not supported")
 	case io.SeekCurrent:
-		offset += int64(consts.BlockLen*d.counter) - int64(d.bufn)
+		offset += int64(d.position())
 	default:
 		return 0, fmt.Errorf("invalid whence: %d", whence)
 	}
@@ -75,6 +151,62 @@ func (d *Digest) Seek(offset int64, whence int) (int64, error) {
 	return offset, nil
 }
 
+// MarshalBinary implements encoding.BinaryMarshaler. It returns the state of
+// the Digest, including the current position, so that it can be restored
+// with UnmarshalBinary, possibly in another process.
+func (d *Digest) MarshalBinary() ([]byte, error) {
+	return d.AppendBinary(make([]byte, 0, digestMarshaledSize))
+}
+
+// AppendBinary is like MarshalBinary but appends the state to b.
+func (d *Digest) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, digestMagic...)
+	for _, w := range d.chain {
+		b = binary.LittleEndian.AppendUint32(b, w)
+	}
+	for _, w := range d.block {
+		b = binary.LittleEndian.AppendUint32(b, w)
+	}
+	b = binary.LittleEndian.AppendUint32(b, d.blen)
+	b = binary.LittleEndian.AppendUint32(b, d.flags)
+	b = binary.LittleEndian.AppendUint64(b, d.position())
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It restores state
+// returned by MarshalBinary or AppendBinary.
+func (d *Digest) UnmarshalBinary(b []byte) error {
+	if len(b) < len(digestMagic) || string(b[:len(digestMagic)]) != digestMagic {
+		return errors.New("invalid digest state identifier")
+	}
+	if len(b) != digestMarshaledSize {
+		return errors.New("invalid digest state size")
+	}
+	b = b[len(digestMagic):]
+
+	var tmp Digest
+	for i := range tmp.chain {
+		tmp.chain[i] = binary.LittleEndian.Uint32(b[4*i:])
+	}
+	for i := range tmp.block {
+		tmp.block[i] = binary.LittleEndian.Uint32(b[32+4*i:])
+	}
+	tmp.blen = binary.LittleEndian.Uint32(b[96:100])
+	tmp.flags = binary.LittleEndian.Uint32(b[100:104])
+
+	if tmp.blen > consts.BlockLen || tmp.flags&consts.Flag_Root == 0 {
+		return errors.New("invalid digest state")
+	}
+
+	tmp.setPosition(binary.LittleEndian.Uint64(b[104:112]))
+	*d = tmp
+	return nil
+}
+
+func (d *Digest) position() uint64 {
+	return consts.BlockLen*d.counter - uint64(d.bufn)
+}
+
 func (d *Digest) setPosition(pos uint64) {
 	d.counter = pos / consts.BlockLen
 	d.fillBuf()
@@ -98,3 +230,62 @@ func (d *Digest) fillBuf() {
 	d.counter++
 	d.bufn = consts.BlockLen
 }
+
+// LimitedDigest is a Digest whose output stream is limited to a fixed number
+// of bytes. Reads return io.EOF at the end of the output and seeking relative
+// to the end is supported, so it can be used anywhere a finite reader is
+// expected.
+type LimitedDigest struct {
+	d Digest
+	n uint64
+}
+
+// Size returns the number of bytes in the output stream.
+func (l *LimitedDigest) Size() int64 { return int64(l.n) }
+
+// Read implements io.Reader. It returns io.EOF once the end of the output has
+// been reached.
+func (l *LimitedDigest) Read(p []byte) (n int, err error) {
+	pos := l.d.position()
+	if pos >= l.n {
+		return 0, io.EOF
+	}
+	if rem := l.n - pos; uint64(len(p)) > rem {
+		p = p[:rem]
+	}
+	return l.d.Read(p)
+}
+
+// ReadAt implements io.ReaderAt. It does not change the position of the
+// LimitedDigest and returns io.EOF if p extends past the end of the output.
+func (l *LimitedDigest) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, fmt.Errorf("read before start")
+	}
+	if uint64(off) >= l.n {
+		return 0, io.EOF
+	}
+	if rem := l.n - uint64(off); uint64(len(p)) > rem {
+		p, err = p[:rem], io.EOF
+	}
+	n, _ = l.d.ReadAt(p, off)
+	return n, err
+}
+
+// WriteTo implements io.WriterTo. It writes the rest of the output to w.
+func (l *LimitedDigest) WriteTo(w io.Writer) (n int64, err error) {
+	pos := l.d.position()
+	if pos >= l.n {
+		return 0, nil
+	}
+	return l.d.writeTo(w, l.n-pos)
+}
+
+// Seek sets the position to the provided location. Seeking past the end is
+// allowed, but reads from there return io.EOF.
+func (l *LimitedDigest) Seek(offset int64, whence int) (int64, error) {
+	if whence == io.SeekEnd {
+		offset, whence = offset+int64(l.n), io.SeekStart
+	}
+	return l.d.Seek(offset, whence)
+}
@@ -0,0 +1,85 @@
+package blake3
+
+import (
+	"errors"
+	"io"
+	"os"
+	"runtime/debug"
+)
+
+// mmapMin is the smallest file worth memory mapping.
+const mmapMin = 16 * 1024
+
+//
+// hashing files and readers
+//
+
+func (a *hasher) readFrom(r io.Reader) (n int64, err error) {
+	if f, ok := r.(*os.File); ok {
+		if n, ok, err := a.readFile(f); ok {
+			return n, err
+		}
+	}
+
+	var buf [32 * 1024]byte
+	for {
+		m, err := r.Read(buf[:])
+		a.update(buf[:m])
+		n += int64(m)
+
+		if err == io.EOF {
+			return n, nil
+		} else if err != nil {
+			return n, err
+		}
+	}
+}
+
+// readFile hashes the rest of f through a memory mapping. It returns false if
+// f is not a large regular file or could not be mapped, in which case it
+// should be read normally.
+func (a *hasher) readFile(f *os.File) (n int64, ok bool, err error) {
+	fi, err := f.Stat()
+	if err != nil || !fi.Mode().IsRegular() {
+		return 0, false, nil
+	}
+	off, err := f.Seek(0, io.SeekCurrent)
+	if err != nil || fi.Size()-off < mmapMin {
+		return 0, false, nil
+	}
+
+	mapping, data, err := mmap(f, off, fi.Size()-off)
+	if err != nil {
+		return 0, false, nil
+	}
+	defer func() {
+		if uerr := munmap(mapping); err == nil {
+			err = uerr
+		}
+	}()
+
+	if err := a.updateMapped(data); err != nil {
+		return 0, true, err
+	}
+	if _, err := f.Seek(int64(len(data)), io.SeekCurrent); err != nil {
+		return 0, true, err
+	}
+
+	return int64(len(data)), true, nil
+}
+
+func (a *hasher) updateMapped(data []byte) (err error) {
+	// a file truncated while mapped faults instead of returning an error
+	defer debug.SetPanicOnFault(debug.SetPanicOnFault(true))
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(interface{ Addr() uintptr }); !ok {
+				panic(r)
+			}
+			err = errors.New("fault reading mapped file")
+		}
+	}()
+
+	a.update(data)
+	return nil
+}
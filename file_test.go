@@ -0,0 +1,115 @@
+package blake3
+
+import (
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/zeebo/assert"
+)
+
+func TestHashFile(t *testing.T) {
+	x := make([]byte, 1<<20+100)
+	for i := range x {
+		x[i] = byte(i) % 251
+	}
+	dir := t.TempDir()
+
+	for _, size := range []int{0, 1, 1024, mmapMin - 1, mmapMin, 8192*3 + 1, 1<<20 + 100} {
+		path := filepath.Join(dir, "file")
+		assert.NoError(t, os.WriteFile(path, x[:size], 0o644))
+
+		exp := Sum256(x[:size])
+		got, err := HashFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, hex.EncodeToString(got[:]), hex.EncodeToString(exp[:]))
+	}
+
+	_, err := HashFile(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestReadFrom(t *testing.T) {
+	x := make([]byte, 1<<20+100)
+	for i := range x {
+		x[i] = byte(i) % 251
+	}
+
+	path := filepath.Join(t.TempDir(), "file")
+	assert.NoError(t, os.WriteFile(path, x, 0o644))
+
+	t.Run("Offset", func(t *testing.T) {
+		for _, off := range []int64{0, 1, 4097, int64(len(x)) - mmapMin, int64(len(x)) - 1} {
+			f, err := os.Open(path)
+			assert.NoError(t, err)
+
+			_, err = f.Seek(off, io.SeekStart)
+			assert.NoError(t, err)
+
+			h := New()
+			_, _ = h.WriteString("prefix")
+			n, err := h.ReadFrom(f)
+			assert.NoError(t, err)
+			assert.Equal(t, n, int64(len(x))-off)
+
+			// the file offset is left at the end as with any other read
+			pos, err := f.Seek(0, io.SeekCurrent)
+			assert.NoError(t, err)
+			assert.Equal(t, pos, int64(len(x)))
+			assert.NoError(t, f.Close())
+
+			exp := New()
+			_, _ = exp.WriteString("prefix")
+			_, _ = exp.Write(x[off:])
+			assert.Equal(t, hex.EncodeToString(h.Sum(nil)), hex.EncodeToString(exp.Sum(nil)))
+		}
+	})
+
+	t.Run("Unmapped", func(t *testing.T) {
+		if runtime.GOOS != "linux" {
+			t.Skip("mappings are only inspected on linux")
+		}
+		mappings := func() int {
+			maps, err := os.ReadFile("/proc/self/maps")
+			assert.NoError(t, err)
+			return strings.Count(string(maps), path)
+		}
+
+		// an unaligned offset maps from the start of the page, and all of
+		// that mapping must be released
+		for _, off := range []int64{0, 1, 4097} {
+			for i := 0; i < 10; i++ {
+				f, err := os.Open(path)
+				assert.NoError(t, err)
+				_, err = f.Seek(off, io.SeekStart)
+				assert.NoError(t, err)
+				_, err = New().ReadFrom(f)
+				assert.NoError(t, err)
+				assert.NoError(t, f.Close())
+			}
+			assert.Equal(t, mappings(), 0)
+		}
+	})
+
+	t.Run("Pipe", func(t *testing.T) {
+		r, w, err := os.Pipe()
+		assert.NoError(t, err)
+		go func() {
+			_, _ = w.Write(x)
+			_ = w.Close()
+		}()
+
+		h := New()
+		n, err := io.Copy(h, r)
+		assert.NoError(t, err)
+		assert.Equal(t, n, int64(len(x)))
+		assert.NoError(t, r.Close())
+
+		exp := Sum256(x)
+		assert.Equal(t, hex.EncodeToString(h.Sum(nil)), hex.EncodeToString(exp[:]))
+	})
+}
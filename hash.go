@@ -0,0 +1,111 @@
+package blake3
+
+import (
+	"crypto/subtle"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// Hash is a 256 bit BLAKE3 output. It is comparable, so it can be used as a
+// map key, but Equal should be used to compare hashes that guard secrets
+// because it runs in constant time.
+//
+// Its text form is lowercase hex, the same as b3sum prints, and it is used for
+// JSON and for values stored in a database.
+type Hash [32]byte
+
+// SumHash returns the first 256 bits of the unkeyed digest of the data as a
+// Hash. It is the same as Sum256.
+func SumHash(data []byte) Hash {
+	return Hash(Sum256(data))
+}
+
+// SumHash returns the first 256 bits of the digest of the Hasher as a Hash,
+// regardless of the size of the Hasher. Like Sum, it does not change the
+// state of the Hasher.
+func (h *Hasher) SumHash() (out Hash) {
+	h.h.finalize(out[:])
+	return out
+}
+
+// ParseHash parses a Hash from 64 hex characters, in either case.
+func ParseHash(s string) (Hash, error) {
+	return FromHex([]byte(s))
+}
+
+// FromHex is like ParseHash but takes the hex characters as bytes.
+func FromHex(b []byte) (out Hash, err error) {
+	if len(b) != hex.EncodedLen(len(out)) {
+		return out, fmt.Errorf("invalid hash length: %d", len(b))
+	}
+	if _, err := hex.Decode(out[:], b); err != nil {
+		return out, errors.New("invalid hash hex")
+	}
+	return out, nil
+}
+
+// String returns the hash as lowercase hex.
+func (h Hash) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+// Equal reports whether h and o are the same hash in constant time.
+func (h Hash) Equal(o Hash) bool {
+	return subtle.ConstantTimeCompare(h[:], o[:]) == 1
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (h Hash) MarshalText() ([]byte, error) {
+	b := make([]byte, hex.EncodedLen(len(h)))
+	hex.Encode(b, h[:])
+	return b, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (h *Hash) UnmarshalText(b []byte) (err error) {
+	*h, err = FromHex(b)
+	return err
+}
+
+// MarshalJSON implements json.Marshaler. The hash is encoded as a hex string.
+func (h Hash) MarshalJSON() ([]byte, error) {
+	b := make([]byte, hex.EncodedLen(len(h))+2)
+	b[0], b[len(b)-1] = '"', '"'
+	hex.Encode(b[1:], h[:])
+	return b, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a hex string.
+func (h *Hash) UnmarshalJSON(b []byte) (err error) {
+	if len(b) < 2 || b[0] != '"' || b[len(b)-1] != '"' {
+		return errors.New("invalid hash json")
+	}
+	*h, err = FromHex(b[1 : len(b)-1])
+	return err
+}
+
+// Value implements driver.Valuer. The hash is stored as a hex string.
+func (h Hash) Value() (driver.Value, error) {
+	return h.String(), nil
+}
+
+// Scan implements sql.Scanner. It accepts a hex string, either as a string or
+// as bytes, or the 32 raw bytes of the hash, such as from a bytea column.
+func (h *Hash) Scan(src any) (err error) {
+	switch src := src.(type) {
+	case string:
+		*h, err = ParseHash(src)
+		return err
+	case []byte:
+		if len(src) == len(h) {
+			copy(h[:], src)
+			return nil
+		}
+		*h, err = FromHex(src)
+		return err
+	default:
+		return fmt.Errorf("cannot scan %T into a hash", src)
+	}
+}
@@ -0,0 +1,99 @@
+package blake3
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/zeebo/assert"
+)
+
+var (
+	_ encoding.TextMarshaler   = Hash{}
+	_ encoding.TextUnmarshaler = (*Hash)(nil)
+	_ json.Marshaler           = Hash{}
+	_ json.Unmarshaler         = (*Hash)(nil)
+	_ driver.Valuer            = Hash{}
+	_ sql.Scanner              = (*Hash)(nil)
+)
+
+const someDataHex = "b224a1da2bf5e72b337dc6dde457a05265a06dec8875be379e2ad2be5edb3bf2"
+
+func TestHash(t *testing.T) {
+	h := SumHash([]byte("some data"))
+	assert.Equal(t, h.String(), someDataHex)
+	assert.Equal(t, h, Hash(Sum256([]byte("some data"))))
+
+	hasher := New()
+	_, _ = hasher.WriteString("some data")
+	assert.Equal(t, hasher.SumHash(), h)
+
+	hasher.size = 64
+	assert.Equal(t, hasher.SumHash(), h)
+
+	got, err := ParseHash(strings.ToUpper(someDataHex))
+	assert.NoError(t, err)
+	assert.That(t, got.Equal(h))
+
+	got, err = FromHex([]byte(someDataHex))
+	assert.NoError(t, err)
+	assert.That(t, got.Equal(h))
+
+	got[31] ^= 1
+	assert.That(t, !got.Equal(h))
+
+	for _, s := range []string{"", someDataHex[:62], someDataHex + "00", "x" + someDataHex[1:]} {
+		_, err := ParseHash(s)
+		assert.Error(t, err)
+	}
+}
+
+func TestHash_Encoding(t *testing.T) {
+	h := SumHash([]byte("some data"))
+
+	text, err := h.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, string(text), someDataHex)
+
+	var got Hash
+	assert.NoError(t, got.UnmarshalText(text))
+	assert.Equal(t, got, h)
+
+	type doc struct {
+		Hash Hash            `json:"hash"`
+		Keys map[Hash]string `json:"keys"`
+	}
+	data, err := json.Marshal(doc{Hash: h, Keys: map[Hash]string{h: "x"}})
+	assert.NoError(t, err)
+	assert.Equal(t, string(data), `{"hash":"`+someDataHex+`","keys":{"`+someDataHex+`":"x"}}`)
+
+	var d doc
+	assert.NoError(t, json.Unmarshal(data, &d))
+	assert.Equal(t, d.Hash, h)
+	assert.Equal(t, d.Keys[h], "x")
+
+	assert.Error(t, json.Unmarshal([]byte(`{"hash":1}`), &d))
+	assert.Error(t, json.Unmarshal([]byte(`{"hash":"abc"}`), &d))
+}
+
+func TestHash_SQL(t *testing.T) {
+	h := SumHash([]byte("some data"))
+
+	v, err := h.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, v, someDataHex)
+
+	for _, src := range []any{someDataHex, []byte(someDataHex), h[:]} {
+		var got Hash
+		assert.NoError(t, got.Scan(src))
+		assert.Equal(t, got, h)
+	}
+
+	for _, src := range []any{nil, 5, "abc", []byte("abc")} {
+		var got Hash
+		assert.Error(t, got.Scan(src))
+	}
+}
@@ -0,0 +1,254 @@
+// Package hazmat exposes the chaining values of BLAKE3 subtrees so that parts
+// of an input can be hashed separately, possibly on different machines, and
+// merged into the normal BLAKE3 root later.
+//
+// The functions in this package do not check that subtrees are merged in the
+// shape of the BLAKE3 tree. Merging the wrong chaining values silently
+// produces a hash that does not match blake3.Sum256, so prefer the blake3
+// package unless you need control over the tree.
+package hazmat
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+
+	"github.com/zeebo/blake3"
+	"github.com/zeebo/blake3/internal/alg"
+	"github.com/zeebo/blake3/internal/consts"
+	"github.com/zeebo/blake3/internal/digest"
+	"github.com/zeebo/blake3/internal/utils"
+)
+
+// ChunkLen is the number of bytes in a chunk, the leaves of the BLAKE3 tree.
+// Subtrees begin at multiples of ChunkLen.
+const ChunkLen = consts.ChunkLen
+
+// ChainingValue is the 32 byte chaining value of a non-root subtree.
+type ChainingValue [32]byte
+
+// ContextKey is the key used to hash the key material in the derive key
+// mode. It is computed from the context string by HashDeriveKeyContext.
+type ContextKey [32]byte
+
+// Mode selects between the unkeyed, keyed and derive key modes of BLAKE3.
+// Every chaining value of a tree must be computed and merged with the same
+// Mode.
+type Mode struct {
+	key   [8]uint32
+	flags uint32
+}
+
+// Hash returns the Mode used by blake3.New and blake3.Sum256.
+func Hash() Mode {
+	return Mode{key: consts.IV}
+}
+
+// KeyedHash returns the Mode used by blake3.NewKeyed with the given key.
+func KeyedHash(key *[32]byte) Mode {
+	m := Mode{flags: consts.Flag_Keyed}
+	utils.KeyFromBytes(key[:], &m.key)
+	return m
+}
+
+// DeriveKeyMaterial returns the Mode used by blake3.NewDeriveKey to hash the
+// key material, given the key computed by HashDeriveKeyContext.
+func DeriveKeyMaterial(contextKey *ContextKey) Mode {
+	m := Mode{flags: consts.Flag_DeriveKeyMaterial}
+	utils.KeyFromBytes(contextKey[:], &m.key)
+	return m
+}
+
+// HashDeriveKeyContext returns the key that blake3.NewDeriveKey derives from
+// the context string.
+func HashDeriveKeyContext(context string) (key ContextKey) {
+	m := Mode{key: consts.IV, flags: consts.Flag_DeriveKeyContext}
+	o := m.root([]byte(context))
+	o.sum(key[:])
+	return key
+}
+
+// LeftSubtreeLen returns the number of bytes in the left subtree of an input
+// of the given length, which must be greater than ChunkLen. The left subtree
+// is the largest power of two number of chunks that leaves at least one byte
+// for the right subtree.
+func LeftSubtreeLen(inputLen uint64) uint64 {
+	full := (inputLen - 1) / ChunkLen
+	return ChunkLen << (bits.Len64(full) - 1)
+}
+
+// MaxSubtreeLen returns the largest number of bytes in a subtree that begins
+// at the given offset, which must be a multiple of ChunkLen. It returns false
+// if the offset is zero, where the subtree may be the entire input.
+func MaxSubtreeLen(inputOffset uint64) (uint64, bool) {
+	if inputOffset == 0 {
+		return 0, false
+	}
+	chunks := inputOffset / ChunkLen
+	return ChunkLen << bits.TrailingZeros64(chunks), true
+}
+
+// SubtreeChainingValue returns the chaining value of the subtree made of
+// input beginning at the given offset into the whole input. The offset must
+// be a multiple of ChunkLen and the input must not be longer than
+// MaxSubtreeLen of the offset. Only the last subtree of an input may be
+// shorter than that.
+//
+// The subtree must not be the entire input, which has a root hash instead of
+// a chaining value.
+func SubtreeChainingValue(mode Mode, inputOffset uint64, input []byte) (cv ChainingValue, err error) {
+	if inputOffset%ChunkLen != 0 {
+		return cv, errors.New("offset is not a multiple of the chunk length")
+	}
+	if len(input) == 0 {
+		return cv, errors.New("empty subtree")
+	}
+	if limit, ok := MaxSubtreeLen(inputOffset); ok && uint64(len(input)) > limit {
+		return cv, errors.New("subtree too large for offset")
+	}
+
+	chain := mode.subtree(input, inputOffset/ChunkLen)
+	wordsToChainingValue(&chain, &cv)
+	return cv, nil
+}
+
+// MergeSubtreesNonRoot returns the chaining value of the parent of the left
+// and right subtrees. The parent must not be the root of the tree.
+func MergeSubtreesNonRoot(left, right *ChainingValue, mode Mode) (cv ChainingValue) {
+	o := mode.parent(chainingValueToWords(left), chainingValueToWords(right))
+	chain := o.chainingValue()
+	wordsToChainingValue(&chain, &cv)
+	return cv
+}
+
+// MergeSubtreesRoot returns the first 256 bits of the hash of the input made
+// of the left and right subtrees, which must be the two children of the root.
+func MergeSubtreesRoot(left, right *ChainingValue, mode Mode) (sum [32]byte) {
+	o := mode.parent(chainingValueToWords(left), chainingValueToWords(right))
+	o.sum(sum[:])
+	return sum
+}
+
+// MergeSubtreesRootDigest is like MergeSubtreesRoot but returns a Digest to
+// read and seek through the entire output stream.
+func MergeSubtreesRootDigest(left, right *ChainingValue, mode Mode) *blake3.Digest {
+	o := mode.parent(chainingValueToWords(left), chainingValueToWords(right))
+	return o.digest()
+}
+
+//
+// tree helpers
+//
+
+// output is the input to the last compression of a node in the tree.
+type output struct {
+	chain   [8]uint32
+	block   [16]uint32
+	counter uint64
+	blen    uint32
+	flags   uint32
+}
+
+func (o *output) chainingValue() (chain [8]uint32) {
+	var out [16]uint32
+	alg.Compress(&o.chain, &o.block, o.counter, o.blen, o.flags, &out)
+	return *(*[8]uint32)(out[0:8])
+}
+
+func (o *output) sum(p []byte) {
+	var out [16]uint32
+	var buf [64]byte
+	alg.Compress(&o.chain, &o.block, 0, o.blen, o.flags|consts.Flag_Root, &out)
+	utils.WordsToBytes(&out, buf[:])
+	copy(p, buf[:])
+}
+
+// digest returns a Digest positioned at the start of the output stream.
+func (o *output) digest() *blake3.Digest {
+	return digest.New(&o.chain, &o.block, o.blen, o.flags).(*blake3.Digest)
+}
+
+// root returns the output of the root node of the entire input.
+func (m *Mode) root(input []byte) output {
+	if len(input) <= ChunkLen {
+		return m.chunk(input, 0)
+	}
+	l := LeftSubtreeLen(uint64(len(input)))
+	return m.parent(m.subtree(input[:l], 0), m.subtree(input[l:], l/ChunkLen))
+}
+
+// subtree returns the chaining value of the subtree made of input beginning
+// at the chunk counter ctr.
+func (m *Mode) subtree(input []byte, ctr uint64) [8]uint32 {
+	if len(input) <= ChunkLen {
+		o := m.chunk(input, ctr)
+		return o.chainingValue()
+	}
+
+	// a complete group of 8 chunks is hashed in parallel by HashF
+	if len(input) == 8*ChunkLen {
+		var out [64]uint32
+		var chain [8]uint32
+		alg.HashF((*[8192]byte)(input), 8192, ctr, m.flags, &m.key, &out, &chain)
+
+		var cvs [8][8]uint32
+		for i := range cvs {
+			for j := range cvs[i] {
+				cvs[i][j] = out[i+8*j]
+			}
+		}
+		for n := 8; n > 1; n /= 2 {
+			for i := 0; i < n/2; i++ {
+				o := m.parent(cvs[2*i], cvs[2*i+1])
+				cvs[i] = o.chainingValue()
+			}
+		}
+		return cvs[0]
+	}
+
+	l := LeftSubtreeLen(uint64(len(input)))
+	o := m.parent(m.subtree(input[:l], ctr), m.subtree(input[l:], ctr+l/ChunkLen))
+	return o.chainingValue()
+}
+
+// chunk returns the output of the chunk made of input, which must be at most
+// ChunkLen bytes, at the chunk counter ctr.
+func (m *Mode) chunk(input []byte, ctr uint64) output {
+	o := output{chain: m.key, counter: ctr, flags: m.flags | consts.Flag_ChunkStart}
+
+	var buf [64]byte
+	var out [16]uint32
+	for len(input) > 64 {
+		utils.BytesToWords((*[64]byte)(input), &o.block)
+		alg.Compress(&o.chain, &o.block, ctr, consts.BlockLen, o.flags, &out)
+		o.chain = *(*[8]uint32)(out[0:8])
+		o.flags &^= consts.Flag_ChunkStart
+		input = input[64:]
+	}
+
+	copy(buf[:], input)
+	utils.BytesToWords(&buf, &o.block)
+	o.blen = uint32(len(input))
+	o.flags |= consts.Flag_ChunkEnd
+
+	return o
+}
+
+// parent returns the output of the parent of the left and right chains.
+func (m *Mode) parent(left, right [8]uint32) output {
+	o := output{chain: m.key, blen: consts.BlockLen, flags: m.flags | consts.Flag_Parent}
+	copy(o.block[0:8], left[:])
+	copy(o.block[8:16], right[:])
+	return o
+}
+
+func chainingValueToWords(cv *ChainingValue) (chain [8]uint32) {
+	utils.KeyFromBytes(cv[:], &chain)
+	return chain
+}
+
+func wordsToChainingValue(chain *[8]uint32, cv *ChainingValue) {
+	for i, w := range chain {
+		binary.LittleEndian.PutUint32(cv[4*i:], w)
+	}
+}
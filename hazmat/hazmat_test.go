@@ -0,0 +1,142 @@
+package hazmat
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/zeebo/assert"
+	"github.com/zeebo/blake3"
+)
+
+const (
+	testKey     = "whats the Elvish word for friend"
+	testContext = "BLAKE3 2019-12-27 16:29:52 test vectors context"
+)
+
+func testInput(n int) []byte {
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = uint8(i % 251)
+	}
+	return out
+}
+
+func testModes() map[string]struct {
+	mode Mode
+	new  func() *blake3.Hasher
+} {
+	var key [32]byte
+	copy(key[:], testKey)
+	ck := HashDeriveKeyContext(testContext)
+
+	return map[string]struct {
+		mode Mode
+		new  func() *blake3.Hasher
+	}{
+		"Hash": {Hash(), blake3.New},
+		"Keyed": {KeyedHash(&key), func() *blake3.Hasher {
+			h, _ := blake3.NewKeyed(key[:])
+			return h
+		}},
+		"DeriveKey": {DeriveKeyMaterial(&ck), func() *blake3.Hasher {
+			return blake3.NewDeriveKey(testContext)
+		}},
+	}
+}
+
+// treeChainingValue computes the chaining value of a subtree by splitting it
+// into separately hashed left and right subtrees until they are small.
+func treeChainingValue(t *testing.T, mode Mode, off uint64, input []byte) ChainingValue {
+	if len(input) <= 4*ChunkLen {
+		cv, err := SubtreeChainingValue(mode, off, input)
+		assert.NoError(t, err)
+		return cv
+	}
+	l := LeftSubtreeLen(uint64(len(input)))
+	left := treeChainingValue(t, mode, off, input[:l])
+	right := treeChainingValue(t, mode, off+l, input[l:])
+	return MergeSubtreesNonRoot(&left, &right, mode)
+}
+
+func TestMergeSubtrees(t *testing.T) {
+	x := testInput(100 * ChunkLen)
+
+	for name, m := range testModes() {
+		t.Run(name, func(t *testing.T) {
+			for _, n := range []int{1025, 2048, 2049, 3 * ChunkLen, 8 * ChunkLen, 8*ChunkLen + 1, 31 * ChunkLen, 100 * ChunkLen} {
+				input := x[:n]
+				l := LeftSubtreeLen(uint64(n))
+
+				h := m.new()
+				_, _ = h.Write(input)
+				exp := make([]byte, 200)
+				_, _ = h.Digest().Read(exp)
+
+				// one call per subtree of the root
+				left, err := SubtreeChainingValue(m.mode, 0, input[:l])
+				assert.NoError(t, err)
+				right, err := SubtreeChainingValue(m.mode, l, input[l:])
+				assert.NoError(t, err)
+
+				sum := MergeSubtreesRoot(&left, &right, m.mode)
+				assert.Equal(t, hex.EncodeToString(sum[:]), hex.EncodeToString(exp[:32]))
+
+				// subtrees merged from smaller pieces
+				left = treeChainingValue(t, m.mode, 0, input[:l])
+				right = treeChainingValue(t, m.mode, l, input[l:])
+
+				got := make([]byte, len(exp))
+				_, _ = MergeSubtreesRootDigest(&left, &right, m.mode).Read(got)
+				assert.Equal(t, hex.EncodeToString(got), hex.EncodeToString(exp))
+			}
+		})
+	}
+}
+
+func TestHashDeriveKeyContext(t *testing.T) {
+	key := HashDeriveKeyContext(testContext)
+	m := DeriveKeyMaterial(&key)
+
+	// a two chunk input is enough to compare against the derive key mode
+	input := testInput(2 * ChunkLen)
+	left, _ := SubtreeChainingValue(m, 0, input[:ChunkLen])
+	right, _ := SubtreeChainingValue(m, ChunkLen, input[ChunkLen:])
+	got := MergeSubtreesRoot(&left, &right, m)
+
+	exp := make([]byte, 32)
+	blake3.DeriveKey(testContext, input, exp)
+	assert.Equal(t, hex.EncodeToString(got[:]), hex.EncodeToString(exp))
+}
+
+func TestSubtreeLens(t *testing.T) {
+	assert.Equal(t, LeftSubtreeLen(1025), uint64(1024))
+	assert.Equal(t, LeftSubtreeLen(2048), uint64(1024))
+	assert.Equal(t, LeftSubtreeLen(2049), uint64(2048))
+	assert.Equal(t, LeftSubtreeLen(8*ChunkLen+1), uint64(8*ChunkLen))
+
+	_, ok := MaxSubtreeLen(0)
+	assert.That(t, !ok)
+
+	for off, exp := range map[uint64]uint64{
+		1024:     1024,
+		2048:     2048,
+		3072:     1024,
+		8192:     8192,
+		5 * 8192: 8192,
+	} {
+		got, ok := MaxSubtreeLen(off)
+		assert.That(t, ok)
+		assert.Equal(t, got, exp)
+	}
+}
+
+func TestSubtreeChainingValue_Errors(t *testing.T) {
+	_, err := SubtreeChainingValue(Hash(), 1, []byte("x"))
+	assert.Error(t, err)
+
+	_, err = SubtreeChainingValue(Hash(), ChunkLen, nil)
+	assert.Error(t, err)
+
+	_, err = SubtreeChainingValue(Hash(), 3*ChunkLen, make([]byte, ChunkLen+1))
+	assert.Error(t, err)
+}
@@ -13,6 +13,14 @@ func HashP(left, right *[64]uint32, flags uint32, key *[8]uint32, out *[64]uint3
 	hash.HashP(left, right, flags, key, out, n)
 }
 
+func HashM(input *[8192]byte, blocks, counter uint64, key *[64]uint32, blen, flags *[16][8]uint32, out *[64]uint32) {
+	hash.HashM(input, blocks, counter, key, blen, flags, out)
+}
+
+func HashX(chain *[8]uint32, block *[16]uint32, counter uint64, blen uint32, flags uint32, out *[1024]byte) {
+	hash.HashX(chain, block, counter, blen, flags, out)
+}
+
 func Compress(chain *[8]uint32, block *[16]uint32, counter uint64, blen uint32, flags uint32, out *[16]uint32) {
 	compress.Compress(chain, block, counter, blen, flags, out)
 }
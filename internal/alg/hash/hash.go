@@ -36,3 +36,21 @@ func HashP(left, right *[64]uint32, flags uint32, key *[8]uint32, out *[64]uint3
 		hash_pure.HashP(left, right, flags, key, out, n)
 	}
 }
+
+func HashM(input *[8192]byte, blocks, counter uint64, key *[64]uint32, blen, flags *[16][8]uint32, out *[64]uint32) {
+	if consts.HasAVX2 {
+		hash_avx2.HashM(input, blocks, counter, key, blen, flags, out)
+	} else {
+		hash_pure.HashM(input, blocks, counter, key, blen, flags, out)
+	}
+}
+
+func HashX(chain *[8]uint32, block *[16]uint32, counter uint64, blen uint32, flags uint32, out *[1024]byte) {
+	if consts.HasAVX512 {
+		hash_avx512.HashX(chain, block, counter, blen, flags, out)
+	} else if consts.HasAVX2 {
+		hash_avx2.HashX(chain, block, counter, blen, flags, out)
+	} else {
+		hash_pure.HashX(chain, block, counter, blen, flags, out)
+	}
+}
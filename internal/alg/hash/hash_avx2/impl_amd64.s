@@ -2555,3 +2555,3731 @@ TEXT ·HashP(SB), NOSPLIT, $72-48
 	VMOVDQU Y6, 224(SI)
 	VZEROUPPER
 	RET
+
+// func HashM(input *[8192]byte, blocks uint64, counter uint64, key *[64]uint32, blen *[16][8]uint32, flags *[16][8]uint32, out *[64]uint32)
+// Requires: AVX, AVX2
+TEXT ·HashM(SB), $584-56
+	MOVQ input+0(FP), AX
+	MOVQ blocks+8(FP), CX
+	MOVQ counter+16(FP), DX
+	MOVQ key+24(FP), BX
+	MOVQ blen+32(FP), SI
+	MOVQ flags+40(FP), DI
+	MOVQ out+48(FP), R8
+
+	// Allocate local space and align it
+	LEAQ 31(SP), R9
+	ANDQ $-32, R9
+
+	// Load some params into the stack (avo improvment?)
+	MOVQ DX, 544(R9)
+
+	// Load keys into vectors
+	VMOVDQU (BX), Y0
+	VMOVDQU 32(BX), Y1
+	VMOVDQU 64(BX), Y2
+	VMOVDQU 96(BX), Y3
+	VMOVDQU 128(BX), Y4
+	VMOVDQU 160(BX), Y5
+	VMOVDQU 192(BX), Y6
+	VMOVDQU 224(BX), Y7
+
+	// Convert the number of blocks into the end of the loop
+	SHLQ $0x06, CX
+	XORQ DX, DX
+	XORQ BX, BX
+
+loop:
+	// Load and transpose message vectors
+	VMOVDQU     (AX)(DX*1), Y8
+	VMOVDQU     1024(AX)(DX*1), Y9
+	VMOVDQU     2048(AX)(DX*1), Y10
+	VMOVDQU     3072(AX)(DX*1), Y11
+	VMOVDQU     4096(AX)(DX*1), Y12
+	VMOVDQU     5120(AX)(DX*1), Y13
+	VMOVDQU     6144(AX)(DX*1), Y14
+	VMOVDQU     7168(AX)(DX*1), Y15
+	VMOVDQA     Y0, 512(R9)
+	VPUNPCKLDQ  Y9, Y8, Y0
+	VPUNPCKHDQ  Y9, Y8, Y8
+	VPUNPCKLDQ  Y11, Y10, Y9
+	VPUNPCKHDQ  Y11, Y10, Y10
+	VPUNPCKLDQ  Y13, Y12, Y11
+	VPUNPCKHDQ  Y13, Y12, Y12
+	VPUNPCKLDQ  Y15, Y14, Y13
+	VPUNPCKHDQ  Y15, Y14, Y14
+	VPUNPCKLQDQ Y9, Y0, Y15
+	VPUNPCKHQDQ Y9, Y0, Y0
+	VPUNPCKLQDQ Y10, Y8, Y9
+	VPUNPCKHQDQ Y10, Y8, Y8
+	VPUNPCKLQDQ Y13, Y11, Y10
+	VPUNPCKHQDQ Y13, Y11, Y11
+	VPUNPCKLQDQ Y14, Y12, Y13
+	VPUNPCKHQDQ Y14, Y12, Y12
+	VINSERTI128 $0x01, X10, Y15, Y14
+	VPERM2I128  $0x31, Y10, Y15, Y10
+	VINSERTI128 $0x01, X11, Y0, Y15
+	VPERM2I128  $0x31, Y11, Y0, Y0
+	VINSERTI128 $0x01, X13, Y9, Y11
+	VPERM2I128  $0x31, Y13, Y9, Y9
+	VINSERTI128 $0x01, X12, Y8, Y13
+	VPERM2I128  $0x31, Y12, Y8, Y8
+	VMOVDQU     Y14, (R9)
+	VMOVDQU     Y15, 32(R9)
+	VMOVDQU     Y11, 64(R9)
+	VMOVDQU     Y13, 96(R9)
+	VMOVDQU     Y10, 128(R9)
+	VMOVDQU     Y0, 160(R9)
+	VMOVDQU     Y9, 192(R9)
+	VMOVDQU     Y8, 224(R9)
+	VMOVDQU     32(AX)(DX*1), Y0
+	VMOVDQU     1056(AX)(DX*1), Y8
+	VMOVDQU     2080(AX)(DX*1), Y9
+	VMOVDQU     3104(AX)(DX*1), Y10
+	VMOVDQU     4128(AX)(DX*1), Y11
+	VMOVDQU     5152(AX)(DX*1), Y12
+	VMOVDQU     6176(AX)(DX*1), Y13
+	VMOVDQU     7200(AX)(DX*1), Y14
+	VPUNPCKLDQ  Y8, Y0, Y15
+	VPUNPCKHDQ  Y8, Y0, Y0
+	VPUNPCKLDQ  Y10, Y9, Y8
+	VPUNPCKHDQ  Y10, Y9, Y9
+	VPUNPCKLDQ  Y12, Y11, Y10
+	VPUNPCKHDQ  Y12, Y11, Y11
+	VPUNPCKLDQ  Y14, Y13, Y12
+	VPUNPCKHDQ  Y14, Y13, Y13
+	VPUNPCKLQDQ Y8, Y15, Y14
+	VPUNPCKHQDQ Y8, Y15, Y8
+	VPUNPCKLQDQ Y9, Y0, Y15
+	VPUNPCKHQDQ Y9, Y0, Y0
+	VPUNPCKLQDQ Y12, Y10, Y9
+	VPUNPCKHQDQ Y12, Y10, Y10
+	VPUNPCKLQDQ Y13, Y11, Y12
+	VPUNPCKHQDQ Y13, Y11, Y11
+	VINSERTI128 $0x01, X9, Y14, Y13
+	VPERM2I128  $0x31, Y9, Y14, Y9
+	VINSERTI128 $0x01, X10, Y8, Y14
+	VPERM2I128  $0x31, Y10, Y8, Y8
+	VINSERTI128 $0x01, X12, Y15, Y10
+	VPERM2I128  $0x31, Y12, Y15, Y12
+	VINSERTI128 $0x01, X11, Y0, Y15
+	VPERM2I128  $0x31, Y11, Y0, Y0
+	VMOVDQU     Y13, 256(R9)
+	VMOVDQU     Y14, 288(R9)
+	VMOVDQU     Y10, 320(R9)
+	VMOVDQU     Y15, 352(R9)
+	VMOVDQU     Y9, 384(R9)
+	VMOVDQU     Y8, 416(R9)
+	VMOVDQU     Y12, 448(R9)
+	VMOVDQU     Y0, 480(R9)
+
+	// Load constants for the round
+	VMOVDQA      512(R9), Y0
+	VMOVDQU      (SI)(BX*1), Y8
+	VMOVDQU      (DI)(BX*1), Y9
+	VPBROADCASTD iv<>+0(SB), Y10
+	VPBROADCASTD iv<>+4(SB), Y11
+	VPBROADCASTD iv<>+8(SB), Y12
+	VPBROADCASTD iv<>+12(SB), Y13
+	VPBROADCASTD 544(R9), Y14
+	VPBROADCASTD 548(R9), Y15
+
+	// Perform the rounds
+	// Round 1
+	VPADDD  (R9), Y0, Y0
+	VPADDD  64(R9), Y1, Y1
+	VPADDD  128(R9), Y2, Y2
+	VPADDD  192(R9), Y3, Y3
+	VPADDD  Y4, Y0, Y0
+	VPXOR   Y0, Y14, Y14
+	VPSHUFB rot16_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y5, Y1, Y1
+	VPXOR   Y1, Y15, Y15
+	VPSHUFB rot16_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y8, Y8
+	VPSHUFB rot16_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y7, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y14, Y10, Y10
+	VPXOR   Y10, Y4, Y4
+	VPADDD  Y15, Y11, Y11
+	VPXOR   Y11, Y5, Y5
+	VPADDD  Y8, Y12, Y12
+	VPXOR   Y12, Y6, Y6
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y7, Y7
+	VMOVDQA Y0, 512(R9)
+	VPSRLD  $0x0c, Y4, Y0
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y0, Y4, Y0
+	VPSRLD  $0x0c, Y5, Y4
+	VPSLLD  $0x14, Y5, Y5
+	VPOR    Y4, Y5, Y4
+	VPSRLD  $0x0c, Y6, Y5
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y5, Y6, Y5
+	VPSRLD  $0x0c, Y7, Y6
+	VPSLLD  $0x14, Y7, Y7
+	VPOR    Y6, Y7, Y6
+	VMOVDQA 512(R9), Y7
+	VPADDD  32(R9), Y7, Y7
+	VPADDD  96(R9), Y1, Y1
+	VPADDD  160(R9), Y2, Y2
+	VPADDD  224(R9), Y3, Y3
+	VPADDD  Y0, Y7, Y7
+	VPXOR   Y7, Y14, Y14
+	VPSHUFB rot8_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y15, Y15
+	VPSHUFB rot8_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y5, Y2, Y2
+	VPXOR   Y2, Y8, Y8
+	VPSHUFB rot8_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y6, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y14, Y10, Y10
+	VPXOR   Y10, Y0, Y0
+	VPADDD  Y15, Y11, Y11
+	VPXOR   Y11, Y4, Y4
+	VPADDD  Y8, Y12, Y12
+	VPXOR   Y12, Y5, Y5
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y6, Y6
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x07, Y0, Y7
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y7, Y0, Y0
+	VPSRLD  $0x07, Y4, Y7
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x07, Y5, Y7
+	VPSLLD  $0x19, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x07, Y6, Y7
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VMOVDQA 512(R9), Y7
+	VPADDD  256(R9), Y7, Y7
+	VPADDD  320(R9), Y1, Y1
+	VPADDD  384(R9), Y2, Y2
+	VPADDD  448(R9), Y3, Y3
+	VPADDD  Y4, Y7, Y7
+	VPXOR   Y7, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y5, Y1, Y1
+	VPXOR   Y1, Y14, Y14
+	VPSHUFB rot16_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y15, Y15
+	VPSHUFB rot16_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y8, Y8
+	VPSHUFB rot16_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y9, Y12, Y12
+	VPXOR   Y12, Y4, Y4
+	VPADDD  Y14, Y13, Y13
+	VPXOR   Y13, Y5, Y5
+	VPADDD  Y15, Y10, Y10
+	VPXOR   Y10, Y6, Y6
+	VPADDD  Y8, Y11, Y11
+	VPXOR   Y11, Y0, Y0
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x0c, Y4, Y7
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x0c, Y5, Y7
+	VPSLLD  $0x14, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x0c, Y6, Y7
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VPSRLD  $0x0c, Y0, Y7
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y7, Y0, Y0
+	VMOVDQA 512(R9), Y7
+	VPADDD  288(R9), Y7, Y7
+	VPADDD  352(R9), Y1, Y1
+	VPADDD  416(R9), Y2, Y2
+	VPADDD  480(R9), Y3, Y3
+	VPADDD  Y4, Y7, Y7
+	VPXOR   Y7, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y5, Y1, Y1
+	VPXOR   Y1, Y14, Y14
+	VPSHUFB rot8_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y15, Y15
+	VPSHUFB rot8_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y8, Y8
+	VPSHUFB rot8_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y9, Y12, Y12
+	VPXOR   Y12, Y4, Y4
+	VPADDD  Y14, Y13, Y13
+	VPXOR   Y13, Y5, Y5
+	VPADDD  Y15, Y10, Y10
+	VPXOR   Y10, Y6, Y6
+	VPADDD  Y8, Y11, Y11
+	VPXOR   Y11, Y0, Y0
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x07, Y4, Y7
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x07, Y5, Y7
+	VPSLLD  $0x19, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x07, Y6, Y7
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VPSRLD  $0x07, Y0, Y7
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y7, Y0, Y0
+
+	// Round 2
+	VMOVDQA 512(R9), Y7
+	VPADDD  64(R9), Y7, Y7
+	VPADDD  96(R9), Y1, Y1
+	VPADDD  224(R9), Y2, Y2
+	VPADDD  128(R9), Y3, Y3
+	VPADDD  Y0, Y7, Y7
+	VPXOR   Y7, Y14, Y14
+	VPSHUFB rot16_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y15, Y15
+	VPSHUFB rot16_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y5, Y2, Y2
+	VPXOR   Y2, Y8, Y8
+	VPSHUFB rot16_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y6, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y14, Y10, Y10
+	VPXOR   Y10, Y0, Y0
+	VPADDD  Y15, Y11, Y11
+	VPXOR   Y11, Y4, Y4
+	VPADDD  Y8, Y12, Y12
+	VPXOR   Y12, Y5, Y5
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y6, Y6
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x0c, Y0, Y7
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y7, Y0, Y0
+	VPSRLD  $0x0c, Y4, Y7
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x0c, Y5, Y7
+	VPSLLD  $0x14, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x0c, Y6, Y7
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VMOVDQA 512(R9), Y7
+	VPADDD  192(R9), Y7, Y7
+	VPADDD  320(R9), Y1, Y1
+	VPADDD  (R9), Y2, Y2
+	VPADDD  416(R9), Y3, Y3
+	VPADDD  Y0, Y7, Y7
+	VPXOR   Y7, Y14, Y14
+	VPSHUFB rot8_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y15, Y15
+	VPSHUFB rot8_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y5, Y2, Y2
+	VPXOR   Y2, Y8, Y8
+	VPSHUFB rot8_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y6, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y14, Y10, Y10
+	VPXOR   Y10, Y0, Y0
+	VPADDD  Y15, Y11, Y11
+	VPXOR   Y11, Y4, Y4
+	VPADDD  Y8, Y12, Y12
+	VPXOR   Y12, Y5, Y5
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y6, Y6
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x07, Y0, Y7
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y7, Y0, Y0
+	VPSRLD  $0x07, Y4, Y7
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x07, Y5, Y7
+	VPSLLD  $0x19, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x07, Y6, Y7
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VMOVDQA 512(R9), Y7
+	VPADDD  32(R9), Y7, Y7
+	VPADDD  384(R9), Y1, Y1
+	VPADDD  288(R9), Y2, Y2
+	VPADDD  480(R9), Y3, Y3
+	VPADDD  Y4, Y7, Y7
+	VPXOR   Y7, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y5, Y1, Y1
+	VPXOR   Y1, Y14, Y14
+	VPSHUFB rot16_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y15, Y15
+	VPSHUFB rot16_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y8, Y8
+	VPSHUFB rot16_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y9, Y12, Y12
+	VPXOR   Y12, Y4, Y4
+	VPADDD  Y14, Y13, Y13
+	VPXOR   Y13, Y5, Y5
+	VPADDD  Y15, Y10, Y10
+	VPXOR   Y10, Y6, Y6
+	VPADDD  Y8, Y11, Y11
+	VPXOR   Y11, Y0, Y0
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x0c, Y4, Y7
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x0c, Y5, Y7
+	VPSLLD  $0x14, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x0c, Y6, Y7
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VPSRLD  $0x0c, Y0, Y7
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y7, Y0, Y0
+	VMOVDQA 512(R9), Y7
+	VPADDD  352(R9), Y7, Y7
+	VPADDD  160(R9), Y1, Y1
+	VPADDD  448(R9), Y2, Y2
+	VPADDD  256(R9), Y3, Y3
+	VPADDD  Y4, Y7, Y7
+	VPXOR   Y7, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y5, Y1, Y1
+	VPXOR   Y1, Y14, Y14
+	VPSHUFB rot8_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y15, Y15
+	VPSHUFB rot8_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y8, Y8
+	VPSHUFB rot8_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y9, Y12, Y12
+	VPXOR   Y12, Y4, Y4
+	VPADDD  Y14, Y13, Y13
+	VPXOR   Y13, Y5, Y5
+	VPADDD  Y15, Y10, Y10
+	VPXOR   Y10, Y6, Y6
+	VPADDD  Y8, Y11, Y11
+	VPXOR   Y11, Y0, Y0
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x07, Y4, Y7
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x07, Y5, Y7
+	VPSLLD  $0x19, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x07, Y6, Y7
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VPSRLD  $0x07, Y0, Y7
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y7, Y0, Y0
+
+	// Round 3
+	VMOVDQA 512(R9), Y7
+	VPADDD  96(R9), Y7, Y7
+	VPADDD  320(R9), Y1, Y1
+	VPADDD  416(R9), Y2, Y2
+	VPADDD  224(R9), Y3, Y3
+	VPADDD  Y0, Y7, Y7
+	VPXOR   Y7, Y14, Y14
+	VPSHUFB rot16_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y15, Y15
+	VPSHUFB rot16_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y5, Y2, Y2
+	VPXOR   Y2, Y8, Y8
+	VPSHUFB rot16_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y6, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y14, Y10, Y10
+	VPXOR   Y10, Y0, Y0
+	VPADDD  Y15, Y11, Y11
+	VPXOR   Y11, Y4, Y4
+	VPADDD  Y8, Y12, Y12
+	VPXOR   Y12, Y5, Y5
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y6, Y6
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x0c, Y0, Y7
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y7, Y0, Y0
+	VPSRLD  $0x0c, Y4, Y7
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x0c, Y5, Y7
+	VPSLLD  $0x14, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x0c, Y6, Y7
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VMOVDQA 512(R9), Y7
+	VPADDD  128(R9), Y7, Y7
+	VPADDD  384(R9), Y1, Y1
+	VPADDD  64(R9), Y2, Y2
+	VPADDD  448(R9), Y3, Y3
+	VPADDD  Y0, Y7, Y7
+	VPXOR   Y7, Y14, Y14
+	VPSHUFB rot8_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y15, Y15
+	VPSHUFB rot8_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y5, Y2, Y2
+	VPXOR   Y2, Y8, Y8
+	VPSHUFB rot8_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y6, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y14, Y10, Y10
+	VPXOR   Y10, Y0, Y0
+	VPADDD  Y15, Y11, Y11
+	VPXOR   Y11, Y4, Y4
+	VPADDD  Y8, Y12, Y12
+	VPXOR   Y12, Y5, Y5
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y6, Y6
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x07, Y0, Y7
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y7, Y0, Y0
+	VPSRLD  $0x07, Y4, Y7
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x07, Y5, Y7
+	VPSLLD  $0x19, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x07, Y6, Y7
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VMOVDQA 512(R9), Y7
+	VPADDD  192(R9), Y7, Y7
+	VPADDD  288(R9), Y1, Y1
+	VPADDD  352(R9), Y2, Y2
+	VPADDD  256(R9), Y3, Y3
+	VPADDD  Y4, Y7, Y7
+	VPXOR   Y7, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y5, Y1, Y1
+	VPXOR   Y1, Y14, Y14
+	VPSHUFB rot16_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y15, Y15
+	VPSHUFB rot16_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y8, Y8
+	VPSHUFB rot16_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y9, Y12, Y12
+	VPXOR   Y12, Y4, Y4
+	VPADDD  Y14, Y13, Y13
+	VPXOR   Y13, Y5, Y5
+	VPADDD  Y15, Y10, Y10
+	VPXOR   Y10, Y6, Y6
+	VPADDD  Y8, Y11, Y11
+	VPXOR   Y11, Y0, Y0
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x0c, Y4, Y7
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x0c, Y5, Y7
+	VPSLLD  $0x14, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x0c, Y6, Y7
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VPSRLD  $0x0c, Y0, Y7
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y7, Y0, Y0
+	VMOVDQA 512(R9), Y7
+	VPADDD  160(R9), Y7, Y7
+	VPADDD  (R9), Y1, Y1
+	VPADDD  480(R9), Y2, Y2
+	VPADDD  32(R9), Y3, Y3
+	VPADDD  Y4, Y7, Y7
+	VPXOR   Y7, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y5, Y1, Y1
+	VPXOR   Y1, Y14, Y14
+	VPSHUFB rot8_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y15, Y15
+	VPSHUFB rot8_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y8, Y8
+	VPSHUFB rot8_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y9, Y12, Y12
+	VPXOR   Y12, Y4, Y4
+	VPADDD  Y14, Y13, Y13
+	VPXOR   Y13, Y5, Y5
+	VPADDD  Y15, Y10, Y10
+	VPXOR   Y10, Y6, Y6
+	VPADDD  Y8, Y11, Y11
+	VPXOR   Y11, Y0, Y0
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x07, Y4, Y7
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x07, Y5, Y7
+	VPSLLD  $0x19, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x07, Y6, Y7
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VPSRLD  $0x07, Y0, Y7
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y7, Y0, Y0
+
+	// Round 4
+	VMOVDQA 512(R9), Y7
+	VPADDD  320(R9), Y7, Y7
+	VPADDD  384(R9), Y1, Y1
+	VPADDD  448(R9), Y2, Y2
+	VPADDD  416(R9), Y3, Y3
+	VPADDD  Y0, Y7, Y7
+	VPXOR   Y7, Y14, Y14
+	VPSHUFB rot16_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y15, Y15
+	VPSHUFB rot16_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y5, Y2, Y2
+	VPXOR   Y2, Y8, Y8
+	VPSHUFB rot16_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y6, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y14, Y10, Y10
+	VPXOR   Y10, Y0, Y0
+	VPADDD  Y15, Y11, Y11
+	VPXOR   Y11, Y4, Y4
+	VPADDD  Y8, Y12, Y12
+	VPXOR   Y12, Y5, Y5
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y6, Y6
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x0c, Y0, Y7
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y7, Y0, Y0
+	VPSRLD  $0x0c, Y4, Y7
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x0c, Y5, Y7
+	VPSLLD  $0x14, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x0c, Y6, Y7
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VMOVDQA 512(R9), Y7
+	VPADDD  224(R9), Y7, Y7
+	VPADDD  288(R9), Y1, Y1
+	VPADDD  96(R9), Y2, Y2
+	VPADDD  480(R9), Y3, Y3
+	VPADDD  Y0, Y7, Y7
+	VPXOR   Y7, Y14, Y14
+	VPSHUFB rot8_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y15, Y15
+	VPSHUFB rot8_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y5, Y2, Y2
+	VPXOR   Y2, Y8, Y8
+	VPSHUFB rot8_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y6, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y14, Y10, Y10
+	VPXOR   Y10, Y0, Y0
+	VPADDD  Y15, Y11, Y11
+	VPXOR   Y11, Y4, Y4
+	VPADDD  Y8, Y12, Y12
+	VPXOR   Y12, Y5, Y5
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y6, Y6
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x07, Y0, Y7
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y7, Y0, Y0
+	VPSRLD  $0x07, Y4, Y7
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x07, Y5, Y7
+	VPSLLD  $0x19, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x07, Y6, Y7
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VMOVDQA 512(R9), Y7
+	VPADDD  128(R9), Y7, Y7
+	VPADDD  352(R9), Y1, Y1
+	VPADDD  160(R9), Y2, Y2
+	VPADDD  32(R9), Y3, Y3
+	VPADDD  Y4, Y7, Y7
+	VPXOR   Y7, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y5, Y1, Y1
+	VPXOR   Y1, Y14, Y14
+	VPSHUFB rot16_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y15, Y15
+	VPSHUFB rot16_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y8, Y8
+	VPSHUFB rot16_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y9, Y12, Y12
+	VPXOR   Y12, Y4, Y4
+	VPADDD  Y14, Y13, Y13
+	VPXOR   Y13, Y5, Y5
+	VPADDD  Y15, Y10, Y10
+	VPXOR   Y10, Y6, Y6
+	VPADDD  Y8, Y11, Y11
+	VPXOR   Y11, Y0, Y0
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x0c, Y4, Y7
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x0c, Y5, Y7
+	VPSLLD  $0x14, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x0c, Y6, Y7
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VPSRLD  $0x0c, Y0, Y7
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y7, Y0, Y0
+	VMOVDQA 512(R9), Y7
+	VPADDD  (R9), Y7, Y7
+	VPADDD  64(R9), Y1, Y1
+	VPADDD  256(R9), Y2, Y2
+	VPADDD  192(R9), Y3, Y3
+	VPADDD  Y4, Y7, Y7
+	VPXOR   Y7, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y5, Y1, Y1
+	VPXOR   Y1, Y14, Y14
+	VPSHUFB rot8_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y15, Y15
+	VPSHUFB rot8_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y8, Y8
+	VPSHUFB rot8_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y9, Y12, Y12
+	VPXOR   Y12, Y4, Y4
+	VPADDD  Y14, Y13, Y13
+	VPXOR   Y13, Y5, Y5
+	VPADDD  Y15, Y10, Y10
+	VPXOR   Y10, Y6, Y6
+	VPADDD  Y8, Y11, Y11
+	VPXOR   Y11, Y0, Y0
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x07, Y4, Y7
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x07, Y5, Y7
+	VPSLLD  $0x19, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x07, Y6, Y7
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VPSRLD  $0x07, Y0, Y7
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y7, Y0, Y0
+
+	// Round 5
+	VMOVDQA 512(R9), Y7
+	VPADDD  384(R9), Y7, Y7
+	VPADDD  288(R9), Y1, Y1
+	VPADDD  480(R9), Y2, Y2
+	VPADDD  448(R9), Y3, Y3
+	VPADDD  Y0, Y7, Y7
+	VPXOR   Y7, Y14, Y14
+	VPSHUFB rot16_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y15, Y15
+	VPSHUFB rot16_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y5, Y2, Y2
+	VPXOR   Y2, Y8, Y8
+	VPSHUFB rot16_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y6, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y14, Y10, Y10
+	VPXOR   Y10, Y0, Y0
+	VPADDD  Y15, Y11, Y11
+	VPXOR   Y11, Y4, Y4
+	VPADDD  Y8, Y12, Y12
+	VPXOR   Y12, Y5, Y5
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y6, Y6
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x0c, Y0, Y7
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y7, Y0, Y0
+	VPSRLD  $0x0c, Y4, Y7
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x0c, Y5, Y7
+	VPSLLD  $0x14, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x0c, Y6, Y7
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VMOVDQA 512(R9), Y7
+	VPADDD  416(R9), Y7, Y7
+	VPADDD  352(R9), Y1, Y1
+	VPADDD  320(R9), Y2, Y2
+	VPADDD  256(R9), Y3, Y3
+	VPADDD  Y0, Y7, Y7
+	VPXOR   Y7, Y14, Y14
+	VPSHUFB rot8_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y15, Y15
+	VPSHUFB rot8_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y5, Y2, Y2
+	VPXOR   Y2, Y8, Y8
+	VPSHUFB rot8_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y6, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y14, Y10, Y10
+	VPXOR   Y10, Y0, Y0
+	VPADDD  Y15, Y11, Y11
+	VPXOR   Y11, Y4, Y4
+	VPADDD  Y8, Y12, Y12
+	VPXOR   Y12, Y5, Y5
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y6, Y6
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x07, Y0, Y7
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y7, Y0, Y0
+	VPSRLD  $0x07, Y4, Y7
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x07, Y5, Y7
+	VPSLLD  $0x19, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x07, Y6, Y7
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VMOVDQA 512(R9), Y7
+	VPADDD  224(R9), Y7, Y7
+	VPADDD  160(R9), Y1, Y1
+	VPADDD  (R9), Y2, Y2
+	VPADDD  192(R9), Y3, Y3
+	VPADDD  Y4, Y7, Y7
+	VPXOR   Y7, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y5, Y1, Y1
+	VPXOR   Y1, Y14, Y14
+	VPSHUFB rot16_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y15, Y15
+	VPSHUFB rot16_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y8, Y8
+	VPSHUFB rot16_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y9, Y12, Y12
+	VPXOR   Y12, Y4, Y4
+	VPADDD  Y14, Y13, Y13
+	VPXOR   Y13, Y5, Y5
+	VPADDD  Y15, Y10, Y10
+	VPXOR   Y10, Y6, Y6
+	VPADDD  Y8, Y11, Y11
+	VPXOR   Y11, Y0, Y0
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x0c, Y4, Y7
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x0c, Y5, Y7
+	VPSLLD  $0x14, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x0c, Y6, Y7
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VPSRLD  $0x0c, Y0, Y7
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y7, Y0, Y0
+	VMOVDQA 512(R9), Y7
+	VPADDD  64(R9), Y7, Y7
+	VPADDD  96(R9), Y1, Y1
+	VPADDD  32(R9), Y2, Y2
+	VPADDD  128(R9), Y3, Y3
+	VPADDD  Y4, Y7, Y7
+	VPXOR   Y7, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y5, Y1, Y1
+	VPXOR   Y1, Y14, Y14
+	VPSHUFB rot8_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y15, Y15
+	VPSHUFB rot8_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y8, Y8
+	VPSHUFB rot8_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y9, Y12, Y12
+	VPXOR   Y12, Y4, Y4
+	VPADDD  Y14, Y13, Y13
+	VPXOR   Y13, Y5, Y5
+	VPADDD  Y15, Y10, Y10
+	VPXOR   Y10, Y6, Y6
+	VPADDD  Y8, Y11, Y11
+	VPXOR   Y11, Y0, Y0
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x07, Y4, Y7
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x07, Y5, Y7
+	VPSLLD  $0x19, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x07, Y6, Y7
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VPSRLD  $0x07, Y0, Y7
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y7, Y0, Y0
+
+	// Round 6
+	VMOVDQA 512(R9), Y7
+	VPADDD  288(R9), Y7, Y7
+	VPADDD  352(R9), Y1, Y1
+	VPADDD  256(R9), Y2, Y2
+	VPADDD  480(R9), Y3, Y3
+	VPADDD  Y0, Y7, Y7
+	VPXOR   Y7, Y14, Y14
+	VPSHUFB rot16_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y15, Y15
+	VPSHUFB rot16_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y5, Y2, Y2
+	VPXOR   Y2, Y8, Y8
+	VPSHUFB rot16_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y6, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y14, Y10, Y10
+	VPXOR   Y10, Y0, Y0
+	VPADDD  Y15, Y11, Y11
+	VPXOR   Y11, Y4, Y4
+	VPADDD  Y8, Y12, Y12
+	VPXOR   Y12, Y5, Y5
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y6, Y6
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x0c, Y0, Y7
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y7, Y0, Y0
+	VPSRLD  $0x0c, Y4, Y7
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x0c, Y5, Y7
+	VPSLLD  $0x14, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x0c, Y6, Y7
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VMOVDQA 512(R9), Y7
+	VPADDD  448(R9), Y7, Y7
+	VPADDD  160(R9), Y1, Y1
+	VPADDD  384(R9), Y2, Y2
+	VPADDD  32(R9), Y3, Y3
+	VPADDD  Y0, Y7, Y7
+	VPXOR   Y7, Y14, Y14
+	VPSHUFB rot8_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y15, Y15
+	VPSHUFB rot8_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y5, Y2, Y2
+	VPXOR   Y2, Y8, Y8
+	VPSHUFB rot8_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y6, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y14, Y10, Y10
+	VPXOR   Y10, Y0, Y0
+	VPADDD  Y15, Y11, Y11
+	VPXOR   Y11, Y4, Y4
+	VPADDD  Y8, Y12, Y12
+	VPXOR   Y12, Y5, Y5
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y6, Y6
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x07, Y0, Y7
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y7, Y0, Y0
+	VPSRLD  $0x07, Y4, Y7
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x07, Y5, Y7
+	VPSLLD  $0x19, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x07, Y6, Y7
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VMOVDQA 512(R9), Y7
+	VPADDD  416(R9), Y7, Y7
+	VPADDD  (R9), Y1, Y1
+	VPADDD  64(R9), Y2, Y2
+	VPADDD  128(R9), Y3, Y3
+	VPADDD  Y4, Y7, Y7
+	VPXOR   Y7, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y5, Y1, Y1
+	VPXOR   Y1, Y14, Y14
+	VPSHUFB rot16_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y15, Y15
+	VPSHUFB rot16_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y8, Y8
+	VPSHUFB rot16_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y9, Y12, Y12
+	VPXOR   Y12, Y4, Y4
+	VPADDD  Y14, Y13, Y13
+	VPXOR   Y13, Y5, Y5
+	VPADDD  Y15, Y10, Y10
+	VPXOR   Y10, Y6, Y6
+	VPADDD  Y8, Y11, Y11
+	VPXOR   Y11, Y0, Y0
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x0c, Y4, Y7
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x0c, Y5, Y7
+	VPSLLD  $0x14, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x0c, Y6, Y7
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VPSRLD  $0x0c, Y0, Y7
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y7, Y0, Y0
+	VMOVDQA 512(R9), Y7
+	VPADDD  96(R9), Y7, Y7
+	VPADDD  320(R9), Y1, Y1
+	VPADDD  192(R9), Y2, Y2
+	VPADDD  224(R9), Y3, Y3
+	VPADDD  Y4, Y7, Y7
+	VPXOR   Y7, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y5, Y1, Y1
+	VPXOR   Y1, Y14, Y14
+	VPSHUFB rot8_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y15, Y15
+	VPSHUFB rot8_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y8, Y8
+	VPSHUFB rot8_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y9, Y12, Y12
+	VPXOR   Y12, Y4, Y4
+	VPADDD  Y14, Y13, Y13
+	VPXOR   Y13, Y5, Y5
+	VPADDD  Y15, Y10, Y10
+	VPXOR   Y10, Y6, Y6
+	VPADDD  Y8, Y11, Y11
+	VPXOR   Y11, Y0, Y0
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x07, Y4, Y7
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x07, Y5, Y7
+	VPSLLD  $0x19, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x07, Y6, Y7
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VPSRLD  $0x07, Y0, Y7
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y7, Y0, Y0
+
+	// Round 7
+	VMOVDQA 512(R9), Y7
+	VPADDD  352(R9), Y7, Y7
+	VPADDD  160(R9), Y1, Y1
+	VPADDD  32(R9), Y2, Y2
+	VPADDD  256(R9), Y3, Y3
+	VPADDD  Y0, Y7, Y7
+	VPXOR   Y7, Y14, Y14
+	VPSHUFB rot16_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y15, Y15
+	VPSHUFB rot16_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y5, Y2, Y2
+	VPXOR   Y2, Y8, Y8
+	VPSHUFB rot16_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y6, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y14, Y10, Y10
+	VPXOR   Y10, Y0, Y0
+	VPADDD  Y15, Y11, Y11
+	VPXOR   Y11, Y4, Y4
+	VPADDD  Y8, Y12, Y12
+	VPXOR   Y12, Y5, Y5
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y6, Y6
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x0c, Y0, Y7
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y7, Y0, Y0
+	VPSRLD  $0x0c, Y4, Y7
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x0c, Y5, Y7
+	VPSLLD  $0x14, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x0c, Y6, Y7
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VMOVDQA 512(R9), Y7
+	VPADDD  480(R9), Y7, Y7
+	VPADDD  (R9), Y1, Y1
+	VPADDD  288(R9), Y2, Y2
+	VPADDD  192(R9), Y3, Y3
+	VPADDD  Y0, Y7, Y7
+	VPXOR   Y7, Y14, Y14
+	VPSHUFB rot8_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y15, Y15
+	VPSHUFB rot8_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y5, Y2, Y2
+	VPXOR   Y2, Y8, Y8
+	VPSHUFB rot8_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y6, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y14, Y10, Y10
+	VPXOR   Y10, Y0, Y0
+	VPADDD  Y15, Y11, Y11
+	VPXOR   Y11, Y4, Y4
+	VPADDD  Y8, Y12, Y12
+	VPXOR   Y12, Y5, Y5
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y6, Y6
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x07, Y0, Y7
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y7, Y0, Y0
+	VPSRLD  $0x07, Y4, Y7
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x07, Y5, Y7
+	VPSLLD  $0x19, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x07, Y6, Y7
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VMOVDQA 512(R9), Y7
+	VPADDD  448(R9), Y7, Y7
+	VPADDD  64(R9), Y1, Y1
+	VPADDD  96(R9), Y2, Y2
+	VPADDD  224(R9), Y3, Y3
+	VPADDD  Y4, Y7, Y7
+	VPXOR   Y7, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y5, Y1, Y1
+	VPXOR   Y1, Y14, Y14
+	VPSHUFB rot16_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y15, Y15
+	VPSHUFB rot16_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y8, Y8
+	VPSHUFB rot16_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y9, Y12, Y12
+	VPXOR   Y12, Y4, Y4
+	VPADDD  Y14, Y13, Y13
+	VPXOR   Y13, Y5, Y5
+	VPADDD  Y15, Y10, Y10
+	VPXOR   Y10, Y6, Y6
+	VPADDD  Y8, Y11, Y11
+	VPXOR   Y11, Y0, Y0
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x0c, Y4, Y7
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x0c, Y5, Y7
+	VPSLLD  $0x14, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x0c, Y6, Y7
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VPSRLD  $0x0c, Y0, Y7
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y7, Y0, Y0
+	VMOVDQA 512(R9), Y7
+	VPADDD  320(R9), Y7, Y7
+	VPADDD  384(R9), Y1, Y1
+	VPADDD  128(R9), Y2, Y2
+	VPADDD  416(R9), Y3, Y3
+	VPADDD  Y4, Y7, Y7
+	VPXOR   Y7, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y5, Y1, Y1
+	VPXOR   Y1, Y14, Y14
+	VPSHUFB rot8_shuf<>+0(SB), Y14, Y14
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y15, Y15
+	VPSHUFB rot8_shuf<>+0(SB), Y15, Y15
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y8, Y8
+	VPSHUFB rot8_shuf<>+0(SB), Y8, Y8
+	VPADDD  Y9, Y12, Y12
+	VPXOR   Y12, Y4, Y4
+	VPADDD  Y14, Y13, Y13
+	VPXOR   Y13, Y5, Y5
+	VPADDD  Y15, Y10, Y10
+	VPXOR   Y10, Y6, Y6
+	VPADDD  Y8, Y11, Y11
+	VPXOR   Y11, Y0, Y0
+	VMOVDQA Y7, 512(R9)
+	VPSRLD  $0x07, Y4, Y7
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y7, Y4, Y4
+	VPSRLD  $0x07, Y5, Y7
+	VPSLLD  $0x19, Y5, Y5
+	VPOR    Y7, Y5, Y5
+	VPSRLD  $0x07, Y6, Y7
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y7, Y6, Y6
+	VPSRLD  $0x07, Y0, Y7
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y7, Y0, Y0
+
+	// Finalize rounds
+	VPXOR Y9, Y6, Y6
+	VPXOR 512(R9), Y10, Y7
+	VPXOR Y11, Y1, Y1
+	VPXOR Y12, Y2, Y2
+	VPXOR Y13, Y3, Y3
+	VPXOR Y14, Y0, Y0
+	VPXOR Y15, Y4, Y4
+	VPXOR Y8, Y5, Y5
+
+	// Fix up registers for next iteration
+	VMOVDQU Y7, Y8
+	VMOVDQU Y6, Y7
+	VMOVDQU Y5, Y6
+	VMOVDQU Y4, Y5
+	VMOVDQU Y0, Y4
+	VMOVDQU Y8, Y0
+
+	// Store the chains of the lanes that ended a chunk or parent
+	VMOVDQU    (DI)(BX*1), Y8
+	VPSLLD     $0x1d, Y8, Y9
+	VPSLLD     $0x1e, Y8, Y8
+	VPOR       Y9, Y8, Y8
+	VPMASKMOVD Y0, Y8, (R8)
+	VPMASKMOVD Y1, Y8, 32(R8)
+	VPMASKMOVD Y2, Y8, 64(R8)
+	VPMASKMOVD Y3, Y8, 96(R8)
+	VPMASKMOVD Y4, Y8, 128(R8)
+	VPMASKMOVD Y5, Y8, 160(R8)
+	VPMASKMOVD Y6, Y8, 192(R8)
+	VPMASKMOVD Y7, Y8, 224(R8)
+
+	// Increment and loop
+	ADDQ $0x40, DX
+	ADDQ $0x20, BX
+	CMPQ DX, CX
+	JNE  loop
+
+	// Release the chains
+	VZEROUPPER
+	RET
+
+// func HashX(chain *[8]uint32, block *[16]uint32, counter uint64, blen uint32, flags uint32, out *[1024]byte)
+// Requires: AVX, AVX2
+TEXT ·HashX(SB), $656-40
+	MOVQ chain+0(FP), AX
+	MOVQ block+8(FP), CX
+	MOVQ counter+16(FP), DX
+	MOVL blen+24(FP), BX
+	MOVL flags+28(FP), SI
+	MOVQ out+32(FP), DI
+
+	// Allocate local space and align it
+	LEAQ 31(SP), R8
+	ANDQ $-32, R8
+
+	// Load some params into the stack (avo improvment?)
+	MOVL BX, 616(R8)
+	MOVL SI, 620(R8)
+
+	// Broadcast the message words, which are the same for every block
+	VPBROADCASTD (CX), Y0
+	VMOVDQA      Y0, (R8)
+	VPBROADCASTD 4(CX), Y0
+	VMOVDQA      Y0, 32(R8)
+	VPBROADCASTD 8(CX), Y0
+	VMOVDQA      Y0, 64(R8)
+	VPBROADCASTD 12(CX), Y0
+	VMOVDQA      Y0, 96(R8)
+	VPBROADCASTD 16(CX), Y0
+	VMOVDQA      Y0, 128(R8)
+	VPBROADCASTD 20(CX), Y0
+	VMOVDQA      Y0, 160(R8)
+	VPBROADCASTD 24(CX), Y0
+	VMOVDQA      Y0, 192(R8)
+	VPBROADCASTD 28(CX), Y0
+	VMOVDQA      Y0, 224(R8)
+	VPBROADCASTD 32(CX), Y0
+	VMOVDQA      Y0, 256(R8)
+	VPBROADCASTD 36(CX), Y0
+	VMOVDQA      Y0, 288(R8)
+	VPBROADCASTD 40(CX), Y0
+	VMOVDQA      Y0, 320(R8)
+	VPBROADCASTD 44(CX), Y0
+	VMOVDQA      Y0, 352(R8)
+	VPBROADCASTD 48(CX), Y0
+	VMOVDQA      Y0, 384(R8)
+	VPBROADCASTD 52(CX), Y0
+	VMOVDQA      Y0, 416(R8)
+	VPBROADCASTD 56(CX), Y0
+	VMOVDQA      Y0, 448(R8)
+	VPBROADCASTD 60(CX), Y0
+	VMOVDQA      Y0, 480(R8)
+
+	// Compute blocks 0 through 7
+	// Build and store counter data on the stack
+	MOVQ         DX, 608(R8)
+	VPBROADCASTQ 608(R8), Y0
+	VPADDQ       counter<>+0(SB), Y0, Y0
+	VPBROADCASTQ 608(R8), Y1
+	VPADDQ       counter<>+32(SB), Y1, Y1
+	VPUNPCKLDQ   Y1, Y0, Y2
+	VPUNPCKHDQ   Y1, Y0, Y0
+	VPUNPCKLDQ   Y0, Y2, Y1
+	VPUNPCKHDQ   Y0, Y2, Y0
+	VPERMQ       $0xd8, Y1, Y1
+	VPERMQ       $0xd8, Y0, Y0
+	VMOVDQU      Y1, 544(R8)
+	VMOVDQU      Y0, 576(R8)
+	ADDQ         $0x08, DX
+
+	// Perform the rounds
+	// Round 1
+	VPBROADCASTD (AX), Y0
+	VPADDD       (R8), Y0, Y0
+	VPBROADCASTD 4(AX), Y1
+	VPADDD       64(R8), Y1, Y1
+	VPBROADCASTD 8(AX), Y2
+	VPADDD       128(R8), Y2, Y2
+	VPBROADCASTD 12(AX), Y3
+	VPADDD       192(R8), Y3, Y3
+	VPBROADCASTD 16(AX), Y4
+	VPADDD       Y4, Y0, Y0
+	VMOVDQU      544(R8), Y5
+	VPXOR        Y0, Y5, Y5
+	VPSHUFB      rot16_shuf<>+0(SB), Y5, Y5
+	VPBROADCASTD 20(AX), Y6
+	VPADDD       Y6, Y1, Y1
+	VMOVDQU      576(R8), Y7
+	VPXOR        Y1, Y7, Y7
+	VPSHUFB      rot16_shuf<>+0(SB), Y7, Y7
+	VPBROADCASTD 24(AX), Y8
+	VPADDD       Y8, Y2, Y2
+	VPBROADCASTD 616(R8), Y9
+	VPXOR        Y2, Y9, Y9
+	VPSHUFB      rot16_shuf<>+0(SB), Y9, Y9
+	VPBROADCASTD 28(AX), Y10
+	VPADDD       Y10, Y3, Y3
+	VPBROADCASTD 620(R8), Y11
+	VPXOR        Y3, Y11, Y11
+	VPSHUFB      rot16_shuf<>+0(SB), Y11, Y11
+	VPBROADCASTD iv<>+0(SB), Y12
+	VPADDD       Y5, Y12, Y12
+	VPXOR        Y12, Y4, Y4
+	VPBROADCASTD iv<>+4(SB), Y13
+	VPADDD       Y7, Y13, Y13
+	VPXOR        Y13, Y6, Y6
+	VPBROADCASTD iv<>+8(SB), Y14
+	VPADDD       Y9, Y14, Y14
+	VPXOR        Y14, Y8, Y8
+	VPBROADCASTD iv<>+12(SB), Y15
+	VPADDD       Y11, Y15, Y15
+	VPXOR        Y15, Y10, Y10
+	VMOVDQA      Y0, 512(R8)
+	VPSRLD       $0x0c, Y4, Y0
+	VPSLLD       $0x14, Y4, Y4
+	VPOR         Y0, Y4, Y0
+	VPSRLD       $0x0c, Y6, Y4
+	VPSLLD       $0x14, Y6, Y6
+	VPOR         Y4, Y6, Y4
+	VPSRLD       $0x0c, Y8, Y6
+	VPSLLD       $0x14, Y8, Y8
+	VPOR         Y6, Y8, Y6
+	VPSRLD       $0x0c, Y10, Y8
+	VPSLLD       $0x14, Y10, Y10
+	VPOR         Y8, Y10, Y8
+	VMOVDQA      512(R8), Y10
+	VPADDD       32(R8), Y10, Y10
+	VPADDD       96(R8), Y1, Y1
+	VPADDD       160(R8), Y2, Y2
+	VPADDD       224(R8), Y3, Y3
+	VPADDD       Y0, Y10, Y10
+	VPXOR        Y10, Y5, Y5
+	VPSHUFB      rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD       Y4, Y1, Y1
+	VPXOR        Y1, Y7, Y7
+	VPSHUFB      rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD       Y6, Y2, Y2
+	VPXOR        Y2, Y9, Y9
+	VPSHUFB      rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD       Y8, Y3, Y3
+	VPXOR        Y3, Y11, Y11
+	VPSHUFB      rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD       Y5, Y12, Y12
+	VPXOR        Y12, Y0, Y0
+	VPADDD       Y7, Y13, Y13
+	VPXOR        Y13, Y4, Y4
+	VPADDD       Y9, Y14, Y14
+	VPXOR        Y14, Y6, Y6
+	VPADDD       Y11, Y15, Y15
+	VPXOR        Y15, Y8, Y8
+	VMOVDQA      Y10, 512(R8)
+	VPSRLD       $0x07, Y0, Y10
+	VPSLLD       $0x19, Y0, Y0
+	VPOR         Y10, Y0, Y0
+	VPSRLD       $0x07, Y4, Y10
+	VPSLLD       $0x19, Y4, Y4
+	VPOR         Y10, Y4, Y4
+	VPSRLD       $0x07, Y6, Y10
+	VPSLLD       $0x19, Y6, Y6
+	VPOR         Y10, Y6, Y6
+	VPSRLD       $0x07, Y8, Y10
+	VPSLLD       $0x19, Y8, Y8
+	VPOR         Y10, Y8, Y8
+	VMOVDQA      512(R8), Y10
+	VPADDD       256(R8), Y10, Y10
+	VPADDD       320(R8), Y1, Y1
+	VPADDD       384(R8), Y2, Y2
+	VPADDD       448(R8), Y3, Y3
+	VPADDD       Y4, Y10, Y10
+	VPXOR        Y10, Y11, Y11
+	VPSHUFB      rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD       Y6, Y1, Y1
+	VPXOR        Y1, Y5, Y5
+	VPSHUFB      rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD       Y8, Y2, Y2
+	VPXOR        Y2, Y7, Y7
+	VPSHUFB      rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD       Y0, Y3, Y3
+	VPXOR        Y3, Y9, Y9
+	VPSHUFB      rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD       Y11, Y14, Y14
+	VPXOR        Y14, Y4, Y4
+	VPADDD       Y5, Y15, Y15
+	VPXOR        Y15, Y6, Y6
+	VPADDD       Y7, Y12, Y12
+	VPXOR        Y12, Y8, Y8
+	VPADDD       Y9, Y13, Y13
+	VPXOR        Y13, Y0, Y0
+	VMOVDQA      Y10, 512(R8)
+	VPSRLD       $0x0c, Y4, Y10
+	VPSLLD       $0x14, Y4, Y4
+	VPOR         Y10, Y4, Y4
+	VPSRLD       $0x0c, Y6, Y10
+	VPSLLD       $0x14, Y6, Y6
+	VPOR         Y10, Y6, Y6
+	VPSRLD       $0x0c, Y8, Y10
+	VPSLLD       $0x14, Y8, Y8
+	VPOR         Y10, Y8, Y8
+	VPSRLD       $0x0c, Y0, Y10
+	VPSLLD       $0x14, Y0, Y0
+	VPOR         Y10, Y0, Y0
+	VMOVDQA      512(R8), Y10
+	VPADDD       288(R8), Y10, Y10
+	VPADDD       352(R8), Y1, Y1
+	VPADDD       416(R8), Y2, Y2
+	VPADDD       480(R8), Y3, Y3
+	VPADDD       Y4, Y10, Y10
+	VPXOR        Y10, Y11, Y11
+	VPSHUFB      rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD       Y6, Y1, Y1
+	VPXOR        Y1, Y5, Y5
+	VPSHUFB      rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD       Y8, Y2, Y2
+	VPXOR        Y2, Y7, Y7
+	VPSHUFB      rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD       Y0, Y3, Y3
+	VPXOR        Y3, Y9, Y9
+	VPSHUFB      rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD       Y11, Y14, Y14
+	VPXOR        Y14, Y4, Y4
+	VPADDD       Y5, Y15, Y15
+	VPXOR        Y15, Y6, Y6
+	VPADDD       Y7, Y12, Y12
+	VPXOR        Y12, Y8, Y8
+	VPADDD       Y9, Y13, Y13
+	VPXOR        Y13, Y0, Y0
+	VMOVDQA      Y10, 512(R8)
+	VPSRLD       $0x07, Y4, Y10
+	VPSLLD       $0x19, Y4, Y4
+	VPOR         Y10, Y4, Y4
+	VPSRLD       $0x07, Y6, Y10
+	VPSLLD       $0x19, Y6, Y6
+	VPOR         Y10, Y6, Y6
+	VPSRLD       $0x07, Y8, Y10
+	VPSLLD       $0x19, Y8, Y8
+	VPOR         Y10, Y8, Y8
+	VPSRLD       $0x07, Y0, Y10
+	VPSLLD       $0x19, Y0, Y0
+	VPOR         Y10, Y0, Y0
+
+	// Round 2
+	VMOVDQA 512(R8), Y10
+	VPADDD  64(R8), Y10, Y10
+	VPADDD  96(R8), Y1, Y1
+	VPADDD  224(R8), Y2, Y2
+	VPADDD  128(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  192(R8), Y10, Y10
+	VPADDD  320(R8), Y1, Y1
+	VPADDD  (R8), Y2, Y2
+	VPADDD  416(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  32(R8), Y10, Y10
+	VPADDD  384(R8), Y1, Y1
+	VPADDD  288(R8), Y2, Y2
+	VPADDD  480(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VMOVDQA 512(R8), Y10
+	VPADDD  352(R8), Y10, Y10
+	VPADDD  160(R8), Y1, Y1
+	VPADDD  448(R8), Y2, Y2
+	VPADDD  256(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+
+	// Round 3
+	VMOVDQA 512(R8), Y10
+	VPADDD  96(R8), Y10, Y10
+	VPADDD  320(R8), Y1, Y1
+	VPADDD  416(R8), Y2, Y2
+	VPADDD  224(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  128(R8), Y10, Y10
+	VPADDD  384(R8), Y1, Y1
+	VPADDD  64(R8), Y2, Y2
+	VPADDD  448(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  192(R8), Y10, Y10
+	VPADDD  288(R8), Y1, Y1
+	VPADDD  352(R8), Y2, Y2
+	VPADDD  256(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VMOVDQA 512(R8), Y10
+	VPADDD  160(R8), Y10, Y10
+	VPADDD  (R8), Y1, Y1
+	VPADDD  480(R8), Y2, Y2
+	VPADDD  32(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+
+	// Round 4
+	VMOVDQA 512(R8), Y10
+	VPADDD  320(R8), Y10, Y10
+	VPADDD  384(R8), Y1, Y1
+	VPADDD  448(R8), Y2, Y2
+	VPADDD  416(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  224(R8), Y10, Y10
+	VPADDD  288(R8), Y1, Y1
+	VPADDD  96(R8), Y2, Y2
+	VPADDD  480(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  128(R8), Y10, Y10
+	VPADDD  352(R8), Y1, Y1
+	VPADDD  160(R8), Y2, Y2
+	VPADDD  32(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VMOVDQA 512(R8), Y10
+	VPADDD  (R8), Y10, Y10
+	VPADDD  64(R8), Y1, Y1
+	VPADDD  256(R8), Y2, Y2
+	VPADDD  192(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+
+	// Round 5
+	VMOVDQA 512(R8), Y10
+	VPADDD  384(R8), Y10, Y10
+	VPADDD  288(R8), Y1, Y1
+	VPADDD  480(R8), Y2, Y2
+	VPADDD  448(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  416(R8), Y10, Y10
+	VPADDD  352(R8), Y1, Y1
+	VPADDD  320(R8), Y2, Y2
+	VPADDD  256(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  224(R8), Y10, Y10
+	VPADDD  160(R8), Y1, Y1
+	VPADDD  (R8), Y2, Y2
+	VPADDD  192(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VMOVDQA 512(R8), Y10
+	VPADDD  64(R8), Y10, Y10
+	VPADDD  96(R8), Y1, Y1
+	VPADDD  32(R8), Y2, Y2
+	VPADDD  128(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+
+	// Round 6
+	VMOVDQA 512(R8), Y10
+	VPADDD  288(R8), Y10, Y10
+	VPADDD  352(R8), Y1, Y1
+	VPADDD  256(R8), Y2, Y2
+	VPADDD  480(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  448(R8), Y10, Y10
+	VPADDD  160(R8), Y1, Y1
+	VPADDD  384(R8), Y2, Y2
+	VPADDD  32(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  416(R8), Y10, Y10
+	VPADDD  (R8), Y1, Y1
+	VPADDD  64(R8), Y2, Y2
+	VPADDD  128(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VMOVDQA 512(R8), Y10
+	VPADDD  96(R8), Y10, Y10
+	VPADDD  320(R8), Y1, Y1
+	VPADDD  192(R8), Y2, Y2
+	VPADDD  224(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+
+	// Round 7
+	VMOVDQA 512(R8), Y10
+	VPADDD  352(R8), Y10, Y10
+	VPADDD  160(R8), Y1, Y1
+	VPADDD  32(R8), Y2, Y2
+	VPADDD  256(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  480(R8), Y10, Y10
+	VPADDD  (R8), Y1, Y1
+	VPADDD  288(R8), Y2, Y2
+	VPADDD  192(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  448(R8), Y10, Y10
+	VPADDD  64(R8), Y1, Y1
+	VPADDD  96(R8), Y2, Y2
+	VPADDD  224(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VMOVDQA 512(R8), Y10
+	VPADDD  320(R8), Y10, Y10
+	VPADDD  384(R8), Y1, Y1
+	VPADDD  128(R8), Y2, Y2
+	VPADDD  416(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+
+	// Finalize the output words
+	VPBROADCASTD (AX), Y10
+	VMOVDQA      Y1, 544(R8)
+	VPXOR        Y12, Y10, Y1
+	VPXOR        512(R8), Y12, Y10
+	VPBROADCASTD 4(AX), Y12
+	VMOVDQA      Y2, 512(R8)
+	VPXOR        Y13, Y12, Y2
+	VPXOR        544(R8), Y13, Y12
+	VPBROADCASTD 8(AX), Y13
+	VMOVDQA      Y3, 544(R8)
+	VPXOR        Y14, Y13, Y3
+	VPXOR        512(R8), Y14, Y13
+	VPBROADCASTD 12(AX), Y14
+	VMOVDQA      Y11, 512(R8)
+	VPXOR        Y15, Y14, Y11
+	VPXOR        544(R8), Y15, Y14
+	VPBROADCASTD 16(AX), Y15
+	VMOVDQA      Y7, 544(R8)
+	VPXOR        Y5, Y15, Y7
+	VPXOR        Y5, Y0, Y0
+	VPBROADCASTD 20(AX), Y5
+	VPXOR        544(R8), Y5, Y15
+	VPXOR        544(R8), Y4, Y4
+	VPBROADCASTD 24(AX), Y5
+	VMOVDQA      Y6, 544(R8)
+	VPXOR        Y9, Y5, Y6
+	VPXOR        544(R8), Y9, Y5
+	VPBROADCASTD 28(AX), Y9
+	VMOVDQA      Y8, 544(R8)
+	VPXOR        512(R8), Y9, Y8
+	VMOVDQA      512(R8), Y9
+	VPXOR        544(R8), Y9, Y9
+
+	// Transpose the words into blocks and store them
+	VMOVDQA     Y1, 512(R8)
+	VPUNPCKLDQ  Y12, Y10, Y1
+	VPUNPCKHDQ  Y12, Y10, Y10
+	VPUNPCKLDQ  Y14, Y13, Y12
+	VPUNPCKHDQ  Y14, Y13, Y13
+	VPUNPCKLDQ  Y4, Y0, Y14
+	VPUNPCKHDQ  Y4, Y0, Y0
+	VPUNPCKLDQ  Y9, Y5, Y4
+	VPUNPCKHDQ  Y9, Y5, Y5
+	VPUNPCKLQDQ Y12, Y1, Y9
+	VPUNPCKHQDQ Y12, Y1, Y1
+	VPUNPCKLQDQ Y13, Y10, Y12
+	VPUNPCKHQDQ Y13, Y10, Y10
+	VPUNPCKLQDQ Y4, Y14, Y13
+	VPUNPCKHQDQ Y4, Y14, Y4
+	VPUNPCKLQDQ Y5, Y0, Y14
+	VPUNPCKHQDQ Y5, Y0, Y0
+	VINSERTI128 $0x01, X13, Y9, Y5
+	VPERM2I128  $0x31, Y13, Y9, Y9
+	VINSERTI128 $0x01, X4, Y1, Y13
+	VPERM2I128  $0x31, Y4, Y1, Y1
+	VINSERTI128 $0x01, X14, Y12, Y4
+	VPERM2I128  $0x31, Y14, Y12, Y12
+	VINSERTI128 $0x01, X0, Y10, Y14
+	VPERM2I128  $0x31, Y0, Y10, Y0
+	VMOVDQU     Y5, (DI)
+	VMOVDQU     Y13, 64(DI)
+	VMOVDQU     Y4, 128(DI)
+	VMOVDQU     Y14, 192(DI)
+	VMOVDQU     Y9, 256(DI)
+	VMOVDQU     Y1, 320(DI)
+	VMOVDQU     Y12, 384(DI)
+	VMOVDQU     Y0, 448(DI)
+	VMOVDQA     512(R8), Y0
+	VPUNPCKLDQ  Y2, Y0, Y1
+	VPUNPCKHDQ  Y2, Y0, Y0
+	VPUNPCKLDQ  Y11, Y3, Y2
+	VPUNPCKHDQ  Y11, Y3, Y3
+	VPUNPCKLDQ  Y15, Y7, Y4
+	VPUNPCKHDQ  Y15, Y7, Y5
+	VPUNPCKLDQ  Y8, Y6, Y7
+	VPUNPCKHDQ  Y8, Y6, Y6
+	VPUNPCKLQDQ Y2, Y1, Y8
+	VPUNPCKHQDQ Y2, Y1, Y1
+	VPUNPCKLQDQ Y3, Y0, Y2
+	VPUNPCKHQDQ Y3, Y0, Y0
+	VPUNPCKLQDQ Y7, Y4, Y3
+	VPUNPCKHQDQ Y7, Y4, Y4
+	VPUNPCKLQDQ Y6, Y5, Y7
+	VPUNPCKHQDQ Y6, Y5, Y5
+	VINSERTI128 $0x01, X3, Y8, Y6
+	VPERM2I128  $0x31, Y3, Y8, Y3
+	VINSERTI128 $0x01, X4, Y1, Y8
+	VPERM2I128  $0x31, Y4, Y1, Y1
+	VINSERTI128 $0x01, X7, Y2, Y4
+	VPERM2I128  $0x31, Y7, Y2, Y2
+	VINSERTI128 $0x01, X5, Y0, Y7
+	VPERM2I128  $0x31, Y5, Y0, Y0
+	VMOVDQU     Y6, 32(DI)
+	VMOVDQU     Y8, 96(DI)
+	VMOVDQU     Y4, 160(DI)
+	VMOVDQU     Y7, 224(DI)
+	VMOVDQU     Y3, 288(DI)
+	VMOVDQU     Y1, 352(DI)
+	VMOVDQU     Y2, 416(DI)
+	VMOVDQU     Y0, 480(DI)
+
+	// Compute blocks 8 through 15
+	// Build and store counter data on the stack
+	MOVQ         DX, 608(R8)
+	VPBROADCASTQ 608(R8), Y0
+	VPADDQ       counter<>+0(SB), Y0, Y0
+	VPBROADCASTQ 608(R8), Y1
+	VPADDQ       counter<>+32(SB), Y1, Y1
+	VPUNPCKLDQ   Y1, Y0, Y2
+	VPUNPCKHDQ   Y1, Y0, Y0
+	VPUNPCKLDQ   Y0, Y2, Y1
+	VPUNPCKHDQ   Y0, Y2, Y0
+	VPERMQ       $0xd8, Y1, Y1
+	VPERMQ       $0xd8, Y0, Y0
+	VMOVDQU      Y1, 544(R8)
+	VMOVDQU      Y0, 576(R8)
+	ADDQ         $0x08, DX
+
+	// Perform the rounds
+	// Round 1
+	VPBROADCASTD (AX), Y0
+	VPADDD       (R8), Y0, Y0
+	VPBROADCASTD 4(AX), Y1
+	VPADDD       64(R8), Y1, Y1
+	VPBROADCASTD 8(AX), Y2
+	VPADDD       128(R8), Y2, Y2
+	VPBROADCASTD 12(AX), Y3
+	VPADDD       192(R8), Y3, Y3
+	VPBROADCASTD 16(AX), Y4
+	VPADDD       Y4, Y0, Y0
+	VMOVDQU      544(R8), Y5
+	VPXOR        Y0, Y5, Y5
+	VPSHUFB      rot16_shuf<>+0(SB), Y5, Y5
+	VPBROADCASTD 20(AX), Y6
+	VPADDD       Y6, Y1, Y1
+	VMOVDQU      576(R8), Y7
+	VPXOR        Y1, Y7, Y7
+	VPSHUFB      rot16_shuf<>+0(SB), Y7, Y7
+	VPBROADCASTD 24(AX), Y8
+	VPADDD       Y8, Y2, Y2
+	VPBROADCASTD 616(R8), Y9
+	VPXOR        Y2, Y9, Y9
+	VPSHUFB      rot16_shuf<>+0(SB), Y9, Y9
+	VPBROADCASTD 28(AX), Y10
+	VPADDD       Y10, Y3, Y3
+	VPBROADCASTD 620(R8), Y11
+	VPXOR        Y3, Y11, Y11
+	VPSHUFB      rot16_shuf<>+0(SB), Y11, Y11
+	VPBROADCASTD iv<>+0(SB), Y12
+	VPADDD       Y5, Y12, Y12
+	VPXOR        Y12, Y4, Y4
+	VPBROADCASTD iv<>+4(SB), Y13
+	VPADDD       Y7, Y13, Y13
+	VPXOR        Y13, Y6, Y6
+	VPBROADCASTD iv<>+8(SB), Y14
+	VPADDD       Y9, Y14, Y14
+	VPXOR        Y14, Y8, Y8
+	VPBROADCASTD iv<>+12(SB), Y15
+	VPADDD       Y11, Y15, Y15
+	VPXOR        Y15, Y10, Y10
+	VMOVDQA      Y0, 512(R8)
+	VPSRLD       $0x0c, Y4, Y0
+	VPSLLD       $0x14, Y4, Y4
+	VPOR         Y0, Y4, Y0
+	VPSRLD       $0x0c, Y6, Y4
+	VPSLLD       $0x14, Y6, Y6
+	VPOR         Y4, Y6, Y4
+	VPSRLD       $0x0c, Y8, Y6
+	VPSLLD       $0x14, Y8, Y8
+	VPOR         Y6, Y8, Y6
+	VPSRLD       $0x0c, Y10, Y8
+	VPSLLD       $0x14, Y10, Y10
+	VPOR         Y8, Y10, Y8
+	VMOVDQA      512(R8), Y10
+	VPADDD       32(R8), Y10, Y10
+	VPADDD       96(R8), Y1, Y1
+	VPADDD       160(R8), Y2, Y2
+	VPADDD       224(R8), Y3, Y3
+	VPADDD       Y0, Y10, Y10
+	VPXOR        Y10, Y5, Y5
+	VPSHUFB      rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD       Y4, Y1, Y1
+	VPXOR        Y1, Y7, Y7
+	VPSHUFB      rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD       Y6, Y2, Y2
+	VPXOR        Y2, Y9, Y9
+	VPSHUFB      rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD       Y8, Y3, Y3
+	VPXOR        Y3, Y11, Y11
+	VPSHUFB      rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD       Y5, Y12, Y12
+	VPXOR        Y12, Y0, Y0
+	VPADDD       Y7, Y13, Y13
+	VPXOR        Y13, Y4, Y4
+	VPADDD       Y9, Y14, Y14
+	VPXOR        Y14, Y6, Y6
+	VPADDD       Y11, Y15, Y15
+	VPXOR        Y15, Y8, Y8
+	VMOVDQA      Y10, 512(R8)
+	VPSRLD       $0x07, Y0, Y10
+	VPSLLD       $0x19, Y0, Y0
+	VPOR         Y10, Y0, Y0
+	VPSRLD       $0x07, Y4, Y10
+	VPSLLD       $0x19, Y4, Y4
+	VPOR         Y10, Y4, Y4
+	VPSRLD       $0x07, Y6, Y10
+	VPSLLD       $0x19, Y6, Y6
+	VPOR         Y10, Y6, Y6
+	VPSRLD       $0x07, Y8, Y10
+	VPSLLD       $0x19, Y8, Y8
+	VPOR         Y10, Y8, Y8
+	VMOVDQA      512(R8), Y10
+	VPADDD       256(R8), Y10, Y10
+	VPADDD       320(R8), Y1, Y1
+	VPADDD       384(R8), Y2, Y2
+	VPADDD       448(R8), Y3, Y3
+	VPADDD       Y4, Y10, Y10
+	VPXOR        Y10, Y11, Y11
+	VPSHUFB      rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD       Y6, Y1, Y1
+	VPXOR        Y1, Y5, Y5
+	VPSHUFB      rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD       Y8, Y2, Y2
+	VPXOR        Y2, Y7, Y7
+	VPSHUFB      rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD       Y0, Y3, Y3
+	VPXOR        Y3, Y9, Y9
+	VPSHUFB      rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD       Y11, Y14, Y14
+	VPXOR        Y14, Y4, Y4
+	VPADDD       Y5, Y15, Y15
+	VPXOR        Y15, Y6, Y6
+	VPADDD       Y7, Y12, Y12
+	VPXOR        Y12, Y8, Y8
+	VPADDD       Y9, Y13, Y13
+	VPXOR        Y13, Y0, Y0
+	VMOVDQA      Y10, 512(R8)
+	VPSRLD       $0x0c, Y4, Y10
+	VPSLLD       $0x14, Y4, Y4
+	VPOR         Y10, Y4, Y4
+	VPSRLD       $0x0c, Y6, Y10
+	VPSLLD       $0x14, Y6, Y6
+	VPOR         Y10, Y6, Y6
+	VPSRLD       $0x0c, Y8, Y10
+	VPSLLD       $0x14, Y8, Y8
+	VPOR         Y10, Y8, Y8
+	VPSRLD       $0x0c, Y0, Y10
+	VPSLLD       $0x14, Y0, Y0
+	VPOR         Y10, Y0, Y0
+	VMOVDQA      512(R8), Y10
+	VPADDD       288(R8), Y10, Y10
+	VPADDD       352(R8), Y1, Y1
+	VPADDD       416(R8), Y2, Y2
+	VPADDD       480(R8), Y3, Y3
+	VPADDD       Y4, Y10, Y10
+	VPXOR        Y10, Y11, Y11
+	VPSHUFB      rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD       Y6, Y1, Y1
+	VPXOR        Y1, Y5, Y5
+	VPSHUFB      rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD       Y8, Y2, Y2
+	VPXOR        Y2, Y7, Y7
+	VPSHUFB      rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD       Y0, Y3, Y3
+	VPXOR        Y3, Y9, Y9
+	VPSHUFB      rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD       Y11, Y14, Y14
+	VPXOR        Y14, Y4, Y4
+	VPADDD       Y5, Y15, Y15
+	VPXOR        Y15, Y6, Y6
+	VPADDD       Y7, Y12, Y12
+	VPXOR        Y12, Y8, Y8
+	VPADDD       Y9, Y13, Y13
+	VPXOR        Y13, Y0, Y0
+	VMOVDQA      Y10, 512(R8)
+	VPSRLD       $0x07, Y4, Y10
+	VPSLLD       $0x19, Y4, Y4
+	VPOR         Y10, Y4, Y4
+	VPSRLD       $0x07, Y6, Y10
+	VPSLLD       $0x19, Y6, Y6
+	VPOR         Y10, Y6, Y6
+	VPSRLD       $0x07, Y8, Y10
+	VPSLLD       $0x19, Y8, Y8
+	VPOR         Y10, Y8, Y8
+	VPSRLD       $0x07, Y0, Y10
+	VPSLLD       $0x19, Y0, Y0
+	VPOR         Y10, Y0, Y0
+
+	// Round 2
+	VMOVDQA 512(R8), Y10
+	VPADDD  64(R8), Y10, Y10
+	VPADDD  96(R8), Y1, Y1
+	VPADDD  224(R8), Y2, Y2
+	VPADDD  128(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  192(R8), Y10, Y10
+	VPADDD  320(R8), Y1, Y1
+	VPADDD  (R8), Y2, Y2
+	VPADDD  416(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  32(R8), Y10, Y10
+	VPADDD  384(R8), Y1, Y1
+	VPADDD  288(R8), Y2, Y2
+	VPADDD  480(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VMOVDQA 512(R8), Y10
+	VPADDD  352(R8), Y10, Y10
+	VPADDD  160(R8), Y1, Y1
+	VPADDD  448(R8), Y2, Y2
+	VPADDD  256(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+
+	// Round 3
+	VMOVDQA 512(R8), Y10
+	VPADDD  96(R8), Y10, Y10
+	VPADDD  320(R8), Y1, Y1
+	VPADDD  416(R8), Y2, Y2
+	VPADDD  224(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  128(R8), Y10, Y10
+	VPADDD  384(R8), Y1, Y1
+	VPADDD  64(R8), Y2, Y2
+	VPADDD  448(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  192(R8), Y10, Y10
+	VPADDD  288(R8), Y1, Y1
+	VPADDD  352(R8), Y2, Y2
+	VPADDD  256(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VMOVDQA 512(R8), Y10
+	VPADDD  160(R8), Y10, Y10
+	VPADDD  (R8), Y1, Y1
+	VPADDD  480(R8), Y2, Y2
+	VPADDD  32(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+
+	// Round 4
+	VMOVDQA 512(R8), Y10
+	VPADDD  320(R8), Y10, Y10
+	VPADDD  384(R8), Y1, Y1
+	VPADDD  448(R8), Y2, Y2
+	VPADDD  416(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  224(R8), Y10, Y10
+	VPADDD  288(R8), Y1, Y1
+	VPADDD  96(R8), Y2, Y2
+	VPADDD  480(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  128(R8), Y10, Y10
+	VPADDD  352(R8), Y1, Y1
+	VPADDD  160(R8), Y2, Y2
+	VPADDD  32(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VMOVDQA 512(R8), Y10
+	VPADDD  (R8), Y10, Y10
+	VPADDD  64(R8), Y1, Y1
+	VPADDD  256(R8), Y2, Y2
+	VPADDD  192(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+
+	// Round 5
+	VMOVDQA 512(R8), Y10
+	VPADDD  384(R8), Y10, Y10
+	VPADDD  288(R8), Y1, Y1
+	VPADDD  480(R8), Y2, Y2
+	VPADDD  448(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  416(R8), Y10, Y10
+	VPADDD  352(R8), Y1, Y1
+	VPADDD  320(R8), Y2, Y2
+	VPADDD  256(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  224(R8), Y10, Y10
+	VPADDD  160(R8), Y1, Y1
+	VPADDD  (R8), Y2, Y2
+	VPADDD  192(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VMOVDQA 512(R8), Y10
+	VPADDD  64(R8), Y10, Y10
+	VPADDD  96(R8), Y1, Y1
+	VPADDD  32(R8), Y2, Y2
+	VPADDD  128(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+
+	// Round 6
+	VMOVDQA 512(R8), Y10
+	VPADDD  288(R8), Y10, Y10
+	VPADDD  352(R8), Y1, Y1
+	VPADDD  256(R8), Y2, Y2
+	VPADDD  480(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  448(R8), Y10, Y10
+	VPADDD  160(R8), Y1, Y1
+	VPADDD  384(R8), Y2, Y2
+	VPADDD  32(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  416(R8), Y10, Y10
+	VPADDD  (R8), Y1, Y1
+	VPADDD  64(R8), Y2, Y2
+	VPADDD  128(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VMOVDQA 512(R8), Y10
+	VPADDD  96(R8), Y10, Y10
+	VPADDD  320(R8), Y1, Y1
+	VPADDD  192(R8), Y2, Y2
+	VPADDD  224(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+
+	// Round 7
+	VMOVDQA 512(R8), Y10
+	VPADDD  352(R8), Y10, Y10
+	VPADDD  160(R8), Y1, Y1
+	VPADDD  32(R8), Y2, Y2
+	VPADDD  256(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  480(R8), Y10, Y10
+	VPADDD  (R8), Y1, Y1
+	VPADDD  288(R8), Y2, Y2
+	VPADDD  192(R8), Y3, Y3
+	VPADDD  Y0, Y10, Y10
+	VPXOR   Y10, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y4, Y1, Y1
+	VPXOR   Y1, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y6, Y2, Y2
+	VPXOR   Y2, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y8, Y3, Y3
+	VPXOR   Y3, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y5, Y12, Y12
+	VPXOR   Y12, Y0, Y0
+	VPADDD  Y7, Y13, Y13
+	VPXOR   Y13, Y4, Y4
+	VPADDD  Y9, Y14, Y14
+	VPXOR   Y14, Y6, Y6
+	VPADDD  Y11, Y15, Y15
+	VPXOR   Y15, Y8, Y8
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VMOVDQA 512(R8), Y10
+	VPADDD  448(R8), Y10, Y10
+	VPADDD  64(R8), Y1, Y1
+	VPADDD  96(R8), Y2, Y2
+	VPADDD  224(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot16_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot16_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot16_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot16_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x0c, Y4, Y10
+	VPSLLD  $0x14, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x0c, Y6, Y10
+	VPSLLD  $0x14, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x0c, Y8, Y10
+	VPSLLD  $0x14, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x0c, Y0, Y10
+	VPSLLD  $0x14, Y0, Y0
+	VPOR    Y10, Y0, Y0
+	VMOVDQA 512(R8), Y10
+	VPADDD  320(R8), Y10, Y10
+	VPADDD  384(R8), Y1, Y1
+	VPADDD  128(R8), Y2, Y2
+	VPADDD  416(R8), Y3, Y3
+	VPADDD  Y4, Y10, Y10
+	VPXOR   Y10, Y11, Y11
+	VPSHUFB rot8_shuf<>+0(SB), Y11, Y11
+	VPADDD  Y6, Y1, Y1
+	VPXOR   Y1, Y5, Y5
+	VPSHUFB rot8_shuf<>+0(SB), Y5, Y5
+	VPADDD  Y8, Y2, Y2
+	VPXOR   Y2, Y7, Y7
+	VPSHUFB rot8_shuf<>+0(SB), Y7, Y7
+	VPADDD  Y0, Y3, Y3
+	VPXOR   Y3, Y9, Y9
+	VPSHUFB rot8_shuf<>+0(SB), Y9, Y9
+	VPADDD  Y11, Y14, Y14
+	VPXOR   Y14, Y4, Y4
+	VPADDD  Y5, Y15, Y15
+	VPXOR   Y15, Y6, Y6
+	VPADDD  Y7, Y12, Y12
+	VPXOR   Y12, Y8, Y8
+	VPADDD  Y9, Y13, Y13
+	VPXOR   Y13, Y0, Y0
+	VMOVDQA Y10, 512(R8)
+	VPSRLD  $0x07, Y4, Y10
+	VPSLLD  $0x19, Y4, Y4
+	VPOR    Y10, Y4, Y4
+	VPSRLD  $0x07, Y6, Y10
+	VPSLLD  $0x19, Y6, Y6
+	VPOR    Y10, Y6, Y6
+	VPSRLD  $0x07, Y8, Y10
+	VPSLLD  $0x19, Y8, Y8
+	VPOR    Y10, Y8, Y8
+	VPSRLD  $0x07, Y0, Y10
+	VPSLLD  $0x19, Y0, Y0
+	VPOR    Y10, Y0, Y0
+
+	// Finalize the output words
+	VPBROADCASTD (AX), Y10
+	VMOVDQA      Y1, 544(R8)
+	VPXOR        Y12, Y10, Y1
+	VPXOR        512(R8), Y12, Y10
+	VPBROADCASTD 4(AX), Y12
+	VMOVDQA      Y2, 512(R8)
+	VPXOR        Y13, Y12, Y2
+	VPXOR        544(R8), Y13, Y12
+	VPBROADCASTD 8(AX), Y13
+	VMOVDQA      Y3, 544(R8)
+	VPXOR        Y14, Y13, Y3
+	VPXOR        512(R8), Y14, Y13
+	VPBROADCASTD 12(AX), Y14
+	VMOVDQA      Y11, 512(R8)
+	VPXOR        Y15, Y14, Y11
+	VPXOR        544(R8), Y15, Y14
+	VPBROADCASTD 16(AX), Y15
+	VMOVDQA      Y7, 544(R8)
+	VPXOR        Y5, Y15, Y7
+	VPXOR        Y5, Y0, Y0
+	VPBROADCASTD 20(AX), Y5
+	VPXOR        544(R8), Y5, Y15
+	VPXOR        544(R8), Y4, Y4
+	VPBROADCASTD 24(AX), Y5
+	VMOVDQA      Y6, 544(R8)
+	VPXOR        Y9, Y5, Y6
+	VPXOR        544(R8), Y9, Y5
+	VPBROADCASTD 28(AX), Y9
+	VMOVDQA      Y8, 544(R8)
+	VPXOR        512(R8), Y9, Y8
+	VMOVDQA      512(R8), Y9
+	VPXOR        544(R8), Y9, Y9
+
+	// Transpose the words into blocks and store them
+	VMOVDQA     Y1, 512(R8)
+	VPUNPCKLDQ  Y12, Y10, Y1
+	VPUNPCKHDQ  Y12, Y10, Y10
+	VPUNPCKLDQ  Y14, Y13, Y12
+	VPUNPCKHDQ  Y14, Y13, Y13
+	VPUNPCKLDQ  Y4, Y0, Y14
+	VPUNPCKHDQ  Y4, Y0, Y0
+	VPUNPCKLDQ  Y9, Y5, Y4
+	VPUNPCKHDQ  Y9, Y5, Y5
+	VPUNPCKLQDQ Y12, Y1, Y9
+	VPUNPCKHQDQ Y12, Y1, Y1
+	VPUNPCKLQDQ Y13, Y10, Y12
+	VPUNPCKHQDQ Y13, Y10, Y10
+	VPUNPCKLQDQ Y4, Y14, Y13
+	VPUNPCKHQDQ Y4, Y14, Y4
+	VPUNPCKLQDQ Y5, Y0, Y14
+	VPUNPCKHQDQ Y5, Y0, Y0
+	VINSERTI128 $0x01, X13, Y9, Y5
+	VPERM2I128  $0x31, Y13, Y9, Y9
+	VINSERTI128 $0x01, X4, Y1, Y13
+	VPERM2I128  $0x31, Y4, Y1, Y1
+	VINSERTI128 $0x01, X14, Y12, Y4
+	VPERM2I128  $0x31, Y14, Y12, Y12
+	VINSERTI128 $0x01, X0, Y10, Y14
+	VPERM2I128  $0x31, Y0, Y10, Y0
+	VMOVDQU     Y5, 512(DI)
+	VMOVDQU     Y13, 576(DI)
+	VMOVDQU     Y4, 640(DI)
+	VMOVDQU     Y14, 704(DI)
+	VMOVDQU     Y9, 768(DI)
+	VMOVDQU     Y1, 832(DI)
+	VMOVDQU     Y12, 896(DI)
+	VMOVDQU     Y0, 960(DI)
+	VMOVDQA     512(R8), Y0
+	VPUNPCKLDQ  Y2, Y0, Y1
+	VPUNPCKHDQ  Y2, Y0, Y0
+	VPUNPCKLDQ  Y11, Y3, Y2
+	VPUNPCKHDQ  Y11, Y3, Y3
+	VPUNPCKLDQ  Y15, Y7, Y4
+	VPUNPCKHDQ  Y15, Y7, Y5
+	VPUNPCKLDQ  Y8, Y6, Y7
+	VPUNPCKHDQ  Y8, Y6, Y6
+	VPUNPCKLQDQ Y2, Y1, Y8
+	VPUNPCKHQDQ Y2, Y1, Y1
+	VPUNPCKLQDQ Y3, Y0, Y2
+	VPUNPCKHQDQ Y3, Y0, Y0
+	VPUNPCKLQDQ Y7, Y4, Y3
+	VPUNPCKHQDQ Y7, Y4, Y4
+	VPUNPCKLQDQ Y6, Y5, Y7
+	VPUNPCKHQDQ Y6, Y5, Y5
+	VINSERTI128 $0x01, X3, Y8, Y6
+	VPERM2I128  $0x31, Y3, Y8, Y3
+	VINSERTI128 $0x01, X4, Y1, Y8
+	VPERM2I128  $0x31, Y4, Y1, Y1
+	VINSERTI128 $0x01, X7, Y2, Y4
+	VPERM2I128  $0x31, Y7, Y2, Y2
+	VINSERTI128 $0x01, X5, Y0, Y7
+	VPERM2I128  $0x31, Y5, Y0, Y0
+	VMOVDQU     Y6, 544(DI)
+	VMOVDQU     Y8, 608(DI)
+	VMOVDQU     Y4, 672(DI)
+	VMOVDQU     Y7, 736(DI)
+	VMOVDQU     Y3, 800(DI)
+	VMOVDQU     Y1, 864(DI)
+	VMOVDQU     Y2, 928(DI)
+	VMOVDQU     Y0, 992(DI)
+	VZEROUPPER
+	RET
@@ -12,3 +12,11 @@ func HashF(input *[8192]byte, length, counter uint64, flags uint32, key *[8]uint
 func HashP(left, right *[64]uint32, flags uint32, key *[8]uint32, out *[64]uint32, n int) {
 	hash_pure.HashP(left, right, flags, key, out, n)
 }
+
+func HashM(input *[8192]byte, blocks, counter uint64, key *[64]uint32, blen, flags *[16][8]uint32, out *[64]uint32) {
+	hash_pure.HashM(input, blocks, counter, key, blen, flags, out)
+}
+
+func HashX(chain *[8]uint32, block *[16]uint32, counter uint64, blen uint32, flags uint32, out *[1024]byte) {
+	hash_pure.HashX(chain, block, counter, blen, flags, out)
+}
@@ -74,3 +74,66 @@ func TestHashP(t *testing.T) {
 		}
 	}
 }
+
+func TestHashM(t *testing.T) {
+	if !consts.HasAVX2 {
+		t.SkipNow()
+	}
+
+	var input [8192]byte
+	var key [64]uint32
+	var blen, flags [16][8]uint32
+
+	for blocks := uint64(1); blocks <= 16; blocks++ {
+		var o1, o2 [64]uint32
+
+		ctr := pcg.Uint64()
+		for i := range &input {
+			input[i] = byte(pcg.Uint32())
+		}
+		for i := range &key {
+			key[i] = pcg.Uint32()
+		}
+		for n := range &blen {
+			for i := range &blen[n] {
+				blen[n][i] = pcg.Uint32() % 65
+				flags[n][i] = pcg.Uint32()
+			}
+		}
+
+		hash_avx2.HashM(&input, blocks, ctr, &key, &blen, &flags, &o1)
+		hash_pure.HashM(&input, blocks, ctr, &key, &blen, &flags, &o2)
+
+		assert.Equal(t, o1, o2)
+	}
+}
+
+func TestHashX(t *testing.T) {
+	if !consts.HasAVX2 {
+		t.SkipNow()
+	}
+
+	var chain [8]uint32
+	var block [16]uint32
+
+	for n := 0; n < 100; n++ {
+		var o1, o2 [1024]byte
+
+		for i := range &chain {
+			chain[i] = pcg.Uint32()
+		}
+		for i := range &block {
+			block[i] = pcg.Uint32()
+		}
+		ctr, blen, flags := pcg.Uint64(), pcg.Uint32()%65, pcg.Uint32()
+		if n%10 == 0 {
+			// the counter carries into the high word in the middle
+			ctr = 1<<32 - uint64(n/10)
+		}
+
+		hash_avx2.HashX(&chain, &block, ctr, blen, flags, &o1)
+		hash_pure.HashX(&chain, &block, ctr, blen, flags, &o2)
+
+		assert.Equal(t, o1, o2)
+	}
+}
@@ -8,3 +8,9 @@ func HashF(input *[8192]byte, length, counter uint64, flags uint32, key *[8]uint
 
 //go:noescape
 func HashP(left, right *[64]uint32, flags uint32, key *[8]uint32, out *[64]uint32, n int)
+
+//go:noescape
+func HashM(input *[8192]byte, blocks, counter uint64, key *[64]uint32, blen, flags *[16][8]uint32, out *[64]uint32)
+
+//go:noescape
+func HashX(chain *[8]uint32, block *[16]uint32, counter uint64, blen uint32, flags uint32, out *[1024]byte)
@@ -98,6 +98,24 @@ DATA transpose_idx<>+248(SB)/4, $0x00000000
 DATA transpose_idx<>+252(SB)/4, $0x00000000
 GLOBL transpose_idx<>(SB), RODATA|NOPTR, $256
 
+DATA counter<>+0(SB)/8, $0x0000000000000000
+DATA counter<>+8(SB)/8, $0x0000000000000001
+DATA counter<>+16(SB)/8, $0x0000000000000002
+DATA counter<>+24(SB)/8, $0x0000000000000003
+DATA counter<>+32(SB)/8, $0x0000000000000004
+DATA counter<>+40(SB)/8, $0x0000000000000005
+DATA counter<>+48(SB)/8, $0x0000000000000006
+DATA counter<>+56(SB)/8, $0x0000000000000007
+DATA counter<>+64(SB)/8, $0x0000000000000008
+DATA counter<>+72(SB)/8, $0x0000000000000009
+DATA counter<>+80(SB)/8, $0x000000000000000a
+DATA counter<>+88(SB)/8, $0x000000000000000b
+DATA counter<>+96(SB)/8, $0x000000000000000c
+DATA counter<>+104(SB)/8, $0x000000000000000d
+DATA counter<>+112(SB)/8, $0x000000000000000e
+DATA counter<>+120(SB)/8, $0x000000000000000f
+GLOBL counter<>(SB), RODATA|NOPTR, $128
+
 // func HashF(input *[8192]byte, length uint64, counter uint64, flags uint32, key *[8]uint32, out *[64]uint32, chain *[8]uint32)
 // Requires: AVX, AVX512F, AVX512VL
 TEXT ·HashF(SB), $704-56
@@ -2280,3 +2298,942 @@ TEXT ·HashP(SB), NOSPLIT, $72-48
 	VMOVDQU Y7, 224(SI)
 	VZEROUPPER
 	RET
+
+// func HashX(chain *[8]uint32, block *[16]uint32, counter uint64, blen uint32, flags uint32, out *[1024]byte)
+// Requires: AVX, AVX512F
+TEXT ·HashX(SB), NOSPLIT, $0-40
+	MOVQ chain+0(FP), AX
+	MOVQ block+8(FP), CX
+	MOVQ counter+16(FP), DX
+	MOVL blen+24(FP), BX
+	MOVL flags+28(FP), SI
+	MOVQ out+32(FP), DI
+
+	// Load the chaining value, IV, block length and flags into the state
+	VPBROADCASTD (AX), Z0
+	VPBROADCASTD 4(AX), Z1
+	VPBROADCASTD 8(AX), Z2
+	VPBROADCASTD 12(AX), Z3
+	VPBROADCASTD 16(AX), Z4
+	VPBROADCASTD 20(AX), Z5
+	VPBROADCASTD 24(AX), Z6
+	VPBROADCASTD 28(AX), Z7
+	VPBROADCASTD iv<>+0(SB), Z8
+	VPBROADCASTD iv<>+4(SB), Z9
+	VPBROADCASTD iv<>+8(SB), Z10
+	VPBROADCASTD iv<>+12(SB), Z11
+	VPBROADCASTD BX, Z14
+	VPBROADCASTD SI, Z15
+
+	// Split the counter of every block into its low and high words
+	VPBROADCASTQ DX, Z16
+	VPADDQ       counter<>+0(SB), Z16, Z17
+	VPADDQ       counter<>+64(SB), Z16, Z18
+	VPMOVQD      Z17, Y12
+	VPMOVQD      Z18, Y19
+	VINSERTI64X4 $0x01, Y19, Z12, Z12
+	VPSRLQ       $0x20, Z17, Z17
+	VPSRLQ       $0x20, Z18, Z18
+	VPMOVQD      Z17, Y13
+	VPMOVQD      Z18, Y19
+	VINSERTI64X4 $0x01, Y19, Z13, Z13
+
+	// Round 1
+	VPADDD      Z4, Z0, Z0
+	VPADDD.BCST (CX), Z0, Z0
+	VPXORD      Z0, Z12, Z12
+	VPRORD      $0x10, Z12, Z12
+	VPADDD      Z12, Z8, Z8
+	VPXORD      Z8, Z4, Z4
+	VPRORD      $0x0c, Z4, Z4
+	VPADDD      Z4, Z0, Z0
+	VPADDD.BCST 4(CX), Z0, Z0
+	VPXORD      Z0, Z12, Z12
+	VPRORD      $0x08, Z12, Z12
+	VPADDD      Z12, Z8, Z8
+	VPXORD      Z8, Z4, Z4
+	VPRORD      $0x07, Z4, Z4
+	VPADDD      Z5, Z1, Z1
+	VPADDD.BCST 8(CX), Z1, Z1
+	VPXORD      Z1, Z13, Z13
+	VPRORD      $0x10, Z13, Z13
+	VPADDD      Z13, Z9, Z9
+	VPXORD      Z9, Z5, Z5
+	VPRORD      $0x0c, Z5, Z5
+	VPADDD      Z5, Z1, Z1
+	VPADDD.BCST 12(CX), Z1, Z1
+	VPXORD      Z1, Z13, Z13
+	VPRORD      $0x08, Z13, Z13
+	VPADDD      Z13, Z9, Z9
+	VPXORD      Z9, Z5, Z5
+	VPRORD      $0x07, Z5, Z5
+	VPADDD      Z6, Z2, Z2
+	VPADDD.BCST 16(CX), Z2, Z2
+	VPXORD      Z2, Z14, Z14
+	VPRORD      $0x10, Z14, Z14
+	VPADDD      Z14, Z10, Z10
+	VPXORD      Z10, Z6, Z6
+	VPRORD      $0x0c, Z6, Z6
+	VPADDD      Z6, Z2, Z2
+	VPADDD.BCST 20(CX), Z2, Z2
+	VPXORD      Z2, Z14, Z14
+	VPRORD      $0x08, Z14, Z14
+	VPADDD      Z14, Z10, Z10
+	VPXORD      Z10, Z6, Z6
+	VPRORD      $0x07, Z6, Z6
+	VPADDD      Z7, Z3, Z3
+	VPADDD.BCST 24(CX), Z3, Z3
+	VPXORD      Z3, Z15, Z15
+	VPRORD      $0x10, Z15, Z15
+	VPADDD      Z15, Z11, Z11
+	VPXORD      Z11, Z7, Z7
+	VPRORD      $0x0c, Z7, Z7
+	VPADDD      Z7, Z3, Z3
+	VPADDD.BCST 28(CX), Z3, Z3
+	VPXORD      Z3, Z15, Z15
+	VPRORD      $0x08, Z15, Z15
+	VPADDD      Z15, Z11, Z11
+	VPXORD      Z11, Z7, Z7
+	VPRORD      $0x07, Z7, Z7
+	VPADDD      Z5, Z0, Z0
+	VPADDD.BCST 32(CX), Z0, Z0
+	VPXORD      Z0, Z15, Z15
+	VPRORD      $0x10, Z15, Z15
+	VPADDD      Z15, Z10, Z10
+	VPXORD      Z10, Z5, Z5
+	VPRORD      $0x0c, Z5, Z5
+	VPADDD      Z5, Z0, Z0
+	VPADDD.BCST 36(CX), Z0, Z0
+	VPXORD      Z0, Z15, Z15
+	VPRORD      $0x08, Z15, Z15
+	VPADDD      Z15, Z10, Z10
+	VPXORD      Z10, Z5, Z5
+	VPRORD      $0x07, Z5, Z5
+	VPADDD      Z6, Z1, Z1
+	VPADDD.BCST 40(CX), Z1, Z1
+	VPXORD      Z1, Z12, Z12
+	VPRORD      $0x10, Z12, Z12
+	VPADDD      Z12, Z11, Z11
+	VPXORD      Z11, Z6, Z6
+	VPRORD      $0x0c, Z6, Z6
+	VPADDD      Z6, Z1, Z1
+	VPADDD.BCST 44(CX), Z1, Z1
+	VPXORD      Z1, Z12, Z12
+	VPRORD      $0x08, Z12, Z12
+	VPADDD      Z12, Z11, Z11
+	VPXORD      Z11, Z6, Z6
+	VPRORD      $0x07, Z6, Z6
+	VPADDD      Z7, Z2, Z2
+	VPADDD.BCST 48(CX), Z2, Z2
+	VPXORD      Z2, Z13, Z13
+	VPRORD      $0x10, Z13, Z13
+	VPADDD      Z13, Z8, Z8
+	VPXORD      Z8, Z7, Z7
+	VPRORD      $0x0c, Z7, Z7
+	VPADDD      Z7, Z2, Z2
+	VPADDD.BCST 52(CX), Z2, Z2
+	VPXORD      Z2, Z13, Z13
+	VPRORD      $0x08, Z13, Z13
+	VPADDD      Z13, Z8, Z8
+	VPXORD      Z8, Z7, Z7
+	VPRORD      $0x07, Z7, Z7
+	VPADDD      Z4, Z3, Z3
+	VPADDD.BCST 56(CX), Z3, Z3
+	VPXORD      Z3, Z14, Z14
+	VPRORD      $0x10, Z14, Z14
+	VPADDD      Z14, Z9, Z9
+	VPXORD      Z9, Z4, Z4
+	VPRORD      $0x0c, Z4, Z4
+	VPADDD      Z4, Z3, Z3
+	VPADDD.BCST 60(CX), Z3, Z3
+	VPXORD      Z3, Z14, Z14
+	VPRORD      $0x08, Z14, Z14
+	VPADDD      Z14, Z9, Z9
+	VPXORD      Z9, Z4, Z4
+	VPRORD      $0x07, Z4, Z4
+
+	// Round 2
+	VPADDD      Z4, Z0, Z0
+	VPADDD.BCST 8(CX), Z0, Z0
+	VPXORD      Z0, Z12, Z12
+	VPRORD      $0x10, Z12, Z12
+	VPADDD      Z12, Z8, Z8
+	VPXORD      Z8, Z4, Z4
+	VPRORD      $0x0c, Z4, Z4
+	VPADDD      Z4, Z0, Z0
+	VPADDD.BCST 24(CX), Z0, Z0
+	VPXORD      Z0, Z12, Z12
+	VPRORD      $0x08, Z12, Z12
+	VPADDD      Z12, Z8, Z8
+	VPXORD      Z8, Z4, Z4
+	VPRORD      $0x07, Z4, Z4
+	VPADDD      Z5, Z1, Z1
+	VPADDD.BCST 12(CX), Z1, Z1
+	VPXORD      Z1, Z13, Z13
+	VPRORD      $0x10, Z13, Z13
+	VPADDD      Z13, Z9, Z9
+	VPXORD      Z9, Z5, Z5
+	VPRORD      $0x0c, Z5, Z5
+	VPADDD      Z5, Z1, Z1
+	VPADDD.BCST 40(CX), Z1, Z1
+	VPXORD      Z1, Z13, Z13
+	VPRORD      $0x08, Z13, Z13
+	VPADDD      Z13, Z9, Z9
+	VPXORD      Z9, Z5, Z5
+	VPRORD      $0x07, Z5, Z5
+	VPADDD      Z6, Z2, Z2
+	VPADDD.BCST 28(CX), Z2, Z2
+	VPXORD      Z2, Z14, Z14
+	VPRORD      $0x10, Z14, Z14
+	VPADDD      Z14, Z10, Z10
+	VPXORD      Z10, Z6, Z6
+	VPRORD      $0x0c, Z6, Z6
+	VPADDD      Z6, Z2, Z2
+	VPADDD.BCST (CX), Z2, Z2
+	VPXORD      Z2, Z14, Z14
+	VPRORD      $0x08, Z14, Z14
+	VPADDD      Z14, Z10, Z10
+	VPXORD      Z10, Z6, Z6
+	VPRORD      $0x07, Z6, Z6
+	VPADDD      Z7, Z3, Z3
+	VPADDD.BCST 16(CX), Z3, Z3
+	VPXORD      Z3, Z15, Z15
+	VPRORD      $0x10, Z15, Z15
+	VPADDD      Z15, Z11, Z11
+	VPXORD      Z11, Z7, Z7
+	VPRORD      $0x0c, Z7, Z7
+	VPADDD      Z7, Z3, Z3
+	VPADDD.BCST 52(CX), Z3, Z3
+	VPXORD      Z3, Z15, Z15
+	VPRORD      $0x08, Z15, Z15
+	VPADDD      Z15, Z11, Z11
+	VPXORD      Z11, Z7, Z7
+	VPRORD      $0x07, Z7, Z7
+	VPADDD      Z5, Z0, Z0
+	VPADDD.BCST 4(CX), Z0, Z0
+	VPXORD      Z0, Z15, Z15
+	VPRORD      $0x10, Z15, Z15
+	VPADDD      Z15, Z10, Z10
+	VPXORD      Z10, Z5, Z5
+	VPRORD      $0x0c, Z5, Z5
+	VPADDD      Z5, Z0, Z0
+	VPADDD.BCST 44(CX), Z0, Z0
+	VPXORD      Z0, Z15, Z15
+	VPRORD      $0x08, Z15, Z15
+	VPADDD      Z15, Z10, Z10
+	VPXORD      Z10, Z5, Z5
+	VPRORD      $0x07, Z5, Z5
+	VPADDD      Z6, Z1, Z1
+	VPADDD.BCST 48(CX), Z1, Z1
+	VPXORD      Z1, Z12, Z12
+	VPRORD      $0x10, Z12, Z12
+	VPADDD      Z12, Z11, Z11
+	VPXORD      Z11, Z6, Z6
+	VPRORD      $0x0c, Z6, Z6
+	VPADDD      Z6, Z1, Z1
+	VPADDD.BCST 20(CX), Z1, Z1
+	VPXORD      Z1, Z12, Z12
+	VPRORD      $0x08, Z12, Z12
+	VPADDD      Z12, Z11, Z11
+	VPXORD      Z11, Z6, Z6
+	VPRORD      $0x07, Z6, Z6
+	VPADDD      Z7, Z2, Z2
+	VPADDD.BCST 36(CX), Z2, Z2
+	VPXORD      Z2, Z13, Z13
+	VPRORD      $0x10, Z13, Z13
+	VPADDD      Z13, Z8, Z8
+	VPXORD      Z8, Z7, Z7
+	VPRORD      $0x0c, Z7, Z7
+	VPADDD      Z7, Z2, Z2
+	VPADDD.BCST 56(CX), Z2, Z2
+	VPXORD      Z2, Z13, Z13
+	VPRORD      $0x08, Z13, Z13
+	VPADDD      Z13, Z8, Z8
+	VPXORD      Z8, Z7, Z7
+	VPRORD      $0x07, Z7, Z7
+	VPADDD      Z4, Z3, Z3
+	VPADDD.BCST 60(CX), Z3, Z3
+	VPXORD      Z3, Z14, Z14
+	VPRORD      $0x10, Z14, Z14
+	VPADDD      Z14, Z9, Z9
+	VPXORD      Z9, Z4, Z4
+	VPRORD      $0x0c, Z4, Z4
+	VPADDD      Z4, Z3, Z3
+	VPADDD.BCST 32(CX), Z3, Z3
+	VPXORD      Z3, Z14, Z14
+	VPRORD      $0x08, Z14, Z14
+	VPADDD      Z14, Z9, Z9
+	VPXORD      Z9, Z4, Z4
+	VPRORD      $0x07, Z4, Z4
+
+	// Round 3
+	VPADDD      Z4, Z0, Z0
+	VPADDD.BCST 12(CX), Z0, Z0
+	VPXORD      Z0, Z12, Z12
+	VPRORD      $0x10, Z12, Z12
+	VPADDD      Z12, Z8, Z8
+	VPXORD      Z8, Z4, Z4
+	VPRORD      $0x0c, Z4, Z4
+	VPADDD      Z4, Z0, Z0
+	VPADDD.BCST 16(CX), Z0, Z0
+	VPXORD      Z0, Z12, Z12
+	VPRORD      $0x08, Z12, Z12
+	VPADDD      Z12, Z8, Z8
+	VPXORD      Z8, Z4, Z4
+	VPRORD      $0x07, Z4, Z4
+	VPADDD      Z5, Z1, Z1
+	VPADDD.BCST 40(CX), Z1, Z1
+	VPXORD      Z1, Z13, Z13
+	VPRORD      $0x10, Z13, Z13
+	VPADDD      Z13, Z9, Z9
+	VPXORD      Z9, Z5, Z5
+	VPRORD      $0x0c, Z5, Z5
+	VPADDD      Z5, Z1, Z1
+	VPADDD.BCST 48(CX), Z1, Z1
+	VPXORD      Z1, Z13, Z13
+	VPRORD      $0x08, Z13, Z13
+	VPADDD      Z13, Z9, Z9
+	VPXORD      Z9, Z5, Z5
+	VPRORD      $0x07, Z5, Z5
+	VPADDD      Z6, Z2, Z2
+	VPADDD.BCST 52(CX), Z2, Z2
+	VPXORD      Z2, Z14, Z14
+	VPRORD      $0x10, Z14, Z14
+	VPADDD      Z14, Z10, Z10
+	VPXORD      Z10, Z6, Z6
+	VPRORD      $0x0c, Z6, Z6
+	VPADDD      Z6, Z2, Z2
+	VPADDD.BCST 8(CX), Z2, Z2
+	VPXORD      Z2, Z14, Z14
+	VPRORD      $0x08, Z14, Z14
+	VPADDD      Z14, Z10, Z10
+	VPXORD      Z10, Z6, Z6
+	VPRORD      $0x07, Z6, Z6
+	VPADDD      Z7, Z3, Z3
+	VPADDD.BCST 28(CX), Z3, Z3
+	VPXORD      Z3, Z15, Z15
+	VPRORD      $0x10, Z15, Z15
+	VPADDD      Z15, Z11, Z11
+	VPXORD      Z11, Z7, Z7
+	VPRORD      $0x0c, Z7, Z7
+	VPADDD      Z7, Z3, Z3
+	VPADDD.BCST 56(CX), Z3, Z3
+	VPXORD      Z3, Z15, Z15
+	VPRORD      $0x08, Z15, Z15
+	VPADDD      Z15, Z11, Z11
+	VPXORD      Z11, Z7, Z7
+	VPRORD      $0x07, Z7, Z7
+	VPADDD      Z5, Z0, Z0
+	VPADDD.BCST 24(CX), Z0, Z0
+	VPXORD      Z0, Z15, Z15
+	VPRORD      $0x10, Z15, Z15
+	VPADDD      Z15, Z10, Z10
+	VPXORD      Z10, Z5, Z5
+	VPRORD      $0x0c, Z5, Z5
+	VPADDD      Z5, Z0, Z0
+	VPADDD.BCST 20(CX), Z0, Z0
+	VPXORD      Z0, Z15, Z15
+	VPRORD      $0x08, Z15, Z15
+	VPADDD      Z15, Z10, Z10
+	VPXORD      Z10, Z5, Z5
+	VPRORD      $0x07, Z5, Z5
+	VPADDD      Z6, Z1, Z1
+	VPADDD.BCST 36(CX), Z1, Z1
+	VPXORD      Z1, Z12, Z12
+	VPRORD      $0x10, Z12, Z12
+	VPADDD      Z12, Z11, Z11
+	VPXORD      Z11, Z6, Z6
+	VPRORD      $0x0c, Z6, Z6
+	VPADDD      Z6, Z1, Z1
+	VPADDD.BCST (CX), Z1, Z1
+	VPXORD      Z1, Z12, Z12
+	VPRORD      $0x08, Z12, Z12
+	VPADDD      Z12, Z11, Z11
+	VPXORD      Z11, Z6, Z6
+	VPRORD      $0x07, Z6, Z6
+	VPADDD      Z7, Z2, Z2
+	VPADDD.BCST 44(CX), Z2, Z2
+	VPXORD      Z2, Z13, Z13
+	VPRORD      $0x10, Z13, Z13
+	VPADDD      Z13, Z8, Z8
+	VPXORD      Z8, Z7, Z7
+	VPRORD      $0x0c, Z7, Z7
+	VPADDD      Z7, Z2, Z2
+	VPADDD.BCST 60(CX), Z2, Z2
+	VPXORD      Z2, Z13, Z13
+	VPRORD      $0x08, Z13, Z13
+	VPADDD      Z13, Z8, Z8
+	VPXORD      Z8, Z7, Z7
+	VPRORD      $0x07, Z7, Z7
+	VPADDD      Z4, Z3, Z3
+	VPADDD.BCST 32(CX), Z3, Z3
+	VPXORD      Z3, Z14, Z14
+	VPRORD      $0x10, Z14, Z14
+	VPADDD      Z14, Z9, Z9
+	VPXORD      Z9, Z4, Z4
+	VPRORD      $0x0c, Z4, Z4
+	VPADDD      Z4, Z3, Z3
+	VPADDD.BCST 4(CX), Z3, Z3
+	VPXORD      Z3, Z14, Z14
+	VPRORD      $0x08, Z14, Z14
+	VPADDD      Z14, Z9, Z9
+	VPXORD      Z9, Z4, Z4
+	VPRORD      $0x07, Z4, Z4
+
+	// Round 4
+	VPADDD      Z4, Z0, Z0
+	VPADDD.BCST 40(CX), Z0, Z0
+	VPXORD      Z0, Z12, Z12
+	VPRORD      $0x10, Z12, Z12
+	VPADDD      Z12, Z8, Z8
+	VPXORD      Z8, Z4, Z4
+	VPRORD      $0x0c, Z4, Z4
+	VPADDD      Z4, Z0, Z0
+	VPADDD.BCST 28(CX), Z0, Z0
+	VPXORD      Z0, Z12, Z12
+	VPRORD      $0x08, Z12, Z12
+	VPADDD      Z12, Z8, Z8
+	VPXORD      Z8, Z4, Z4
+	VPRORD      $0x07, Z4, Z4
+	VPADDD      Z5, Z1, Z1
+	VPADDD.BCST 48(CX), Z1, Z1
+	VPXORD      Z1, Z13, Z13
+	VPRORD      $0x10, Z13, Z13
+	VPADDD      Z13, Z9, Z9
+	VPXORD      Z9, Z5, Z5
+	VPRORD      $0x0c, Z5, Z5
+	VPADDD      Z5, Z1, Z1
+	VPADDD.BCST 36(CX), Z1, Z1
+	VPXORD      Z1, Z13, Z13
+	VPRORD      $0x08, Z13, Z13
+	VPADDD      Z13, Z9, Z9
+	VPXORD      Z9, Z5, Z5
+	VPRORD      $0x07, Z5, Z5
+	VPADDD      Z6, Z2, Z2
+	VPADDD.BCST 56(CX), Z2, Z2
+	VPXORD      Z2, Z14, Z14
+	VPRORD      $0x10, Z14, Z14
+	VPADDD      Z14, Z10, Z10
+	VPXORD      Z10, Z6, Z6
+	VPRORD      $0x0c, Z6, Z6
+	VPADDD      Z6, Z2, Z2
+	VPADDD.BCST 12(CX), Z2, Z2
+	VPXORD      Z2, Z14, Z14
+	VPRORD      $0x08, Z14, Z14
+	VPADDD      Z14, Z10, Z10
+	VPXORD      Z10, Z6, Z6
+	VPRORD      $0x07, Z6, Z6
+	VPADDD      Z7, Z3, Z3
+	VPADDD.BCST 52(CX), Z3, Z3
+	VPXORD      Z3, Z15, Z15
+	VPRORD      $0x10, Z15, Z15
+	VPADDD      Z15, Z11, Z11
+	VPXORD      Z11, Z7, Z7
+	VPRORD      $0x0c, Z7, Z7
+	VPADDD      Z7, Z3, Z3
+	VPADDD.BCST 60(CX), Z3, Z3
+	VPXORD      Z3, Z15, Z15
+	VPRORD      $0x08, Z15, Z15
+	VPADDD      Z15, Z11, Z11
+	VPXORD      Z11, Z7, Z7
+	VPRORD      $0x07, Z7, Z7
+	VPADDD      Z5, Z0, Z0
+	VPADDD.BCST 16(CX), Z0, Z0
+	VPXORD      Z0, Z15, Z15
+	VPRORD      $0x10, Z15, Z15
+	VPADDD      Z15, Z10, Z10
+	VPXORD      Z10, Z5, Z5
+	VPRORD      $0x0c, Z5, Z5
+	VPADDD      Z5, Z0, Z0
+	VPADDD.BCST (CX), Z0, Z0
+	VPXORD      Z0, Z15, Z15
+	VPRORD      $0x08, Z15, Z15
+	VPADDD      Z15, Z10, Z10
+	VPXORD      Z10, Z5, Z5
+	VPRORD      $0x07, Z5, Z5
+	VPADDD      Z6, Z1, Z1
+	VPADDD.BCST 44(CX), Z1, Z1
+	VPXORD      Z1, Z12, Z12
+	VPRORD      $0x10, Z12, Z12
+	VPADDD      Z12, Z11, Z11
+	VPXORD      Z11, Z6, Z6
+	VPRORD      $0x0c, Z6, Z6
+	VPADDD      Z6, Z1, Z1
+	VPADDD.BCST 8(CX), Z1, Z1
+	VPXORD      Z1, Z12, Z12
+	VPRORD      $0x08, Z12, Z12
+	VPADDD      Z12, Z11, Z11
+	VPXORD      Z11, Z6, Z6
+	VPRORD      $0x07, Z6, Z6
+	VPADDD      Z7, Z2, Z2
+	VPADDD.BCST 20(CX), Z2, Z2
+	VPXORD      Z2, Z13, Z13
+	VPRORD      $0x10, Z13, Z13
+	VPADDD      Z13, Z8, Z8
+	VPXORD      Z8, Z7, Z7
+	VPRORD      $0x0c, Z7, Z7
+	VPADDD      Z7, Z2, Z2
+	VPADDD.BCST 32(CX), Z2, Z2
+	VPXORD      Z2, Z13, Z13
+	VPRORD      $0x08, Z13, Z13
+	VPADDD      Z13, Z8, Z8
+	VPXORD      Z8, Z7, Z7
+	VPRORD      $0x07, Z7, Z7
+	VPADDD      Z4, Z3, Z3
+	VPADDD.BCST 4(CX), Z3, Z3
+	VPXORD      Z3, Z14, Z14
+	VPRORD      $0x10, Z14, Z14
+	VPADDD      Z14, Z9, Z9
+	VPXORD      Z9, Z4, Z4
+	VPRORD      $0x0c, Z4, Z4
+	VPADDD      Z4, Z3, Z3
+	VPADDD.BCST 24(CX), Z3, Z3
+	VPXORD      Z3, Z14, Z14
+	VPRORD      $0x08, Z14, Z14
+	VPADDD      Z14, Z9, Z9
+	VPXORD      Z9, Z4, Z4
+	VPRORD      $0x07, Z4, Z4
+
+	// Round 5
+	VPADDD      Z4, Z0, Z0
+	VPADDD.BCST 48(CX), Z0, Z0
+	VPXORD      Z0, Z12, Z12
+	VPRORD      $0x10, Z12, Z12
+	VPADDD      Z12, Z8, Z8
+	VPXORD      Z8, Z4, Z4
+	VPRORD      $0x0c, Z4, Z4
+	VPADDD      Z4, Z0, Z0
+	VPADDD.BCST 52(CX), Z0, Z0
+	VPXORD      Z0, Z12, Z12
+	VPRORD      $0x08, Z12, Z12
+	VPADDD      Z12, Z8, Z8
+	VPXORD      Z8, Z4, Z4
+	VPRORD      $0x07, Z4, Z4
+	VPADDD      Z5, Z1, Z1
+	VPADDD.BCST 36(CX), Z1, Z1
+	VPXORD      Z1, Z13, Z13
+	VPRORD      $0x10, Z13, Z13
+	VPADDD      Z13, Z9, Z9
+	VPXORD      Z9, Z5, Z5
+	VPRORD      $0x0c, Z5, Z5
+	VPADDD      Z5, Z1, Z1
+	VPADDD.BCST 44(CX), Z1, Z1
+	VPXORD      Z1, Z13, Z13
+	VPRORD      $0x08, Z13, Z13
+	VPADDD      Z13, Z9, Z9
+	VPXORD      Z9, Z5, Z5
+	VPRORD      $0x07, Z5, Z5
+	VPADDD      Z6, Z2, Z2
+	VPADDD.BCST 60(CX), Z2, Z2
+	VPXORD      Z2, Z14, Z14
+	VPRORD      $0x10, Z14, Z14
+	VPADDD      Z14, Z10, Z10
+	VPXORD      Z10, Z6, Z6
+	VPRORD      $0x0c, Z6, Z6
+	VPADDD      Z6, Z2, Z2
+	VPADDD.BCST 40(CX), Z2, Z2
+	VPXORD      Z2, Z14, Z14
+	VPRORD      $0x08, Z14, Z14
+	VPADDD      Z14, Z10, Z10
+	VPXORD      Z10, Z6, Z6
+	VPRORD      $0x07, Z6, Z6
+	VPADDD      Z7, Z3, Z3
+	VPADDD.BCST 56(CX), Z3, Z3
+	VPXORD      Z3, Z15, Z15
+	VPRORD      $0x10, Z15, Z15
+	VPADDD      Z15, Z11, Z11
+	VPXORD      Z11, Z7, Z7
+	VPRORD      $0x0c, Z7, Z7
+	VPADDD      Z7, Z3, Z3
+	VPADDD.BCST 32(CX), Z3, Z3
+	VPXORD      Z3, Z15, Z15
+	VPRORD      $0x08, Z15, Z15
+	VPADDD      Z15, Z11, Z11
+	VPXORD      Z11, Z7, Z7
+	VPRORD      $0x07, Z7, Z7
+	VPADDD      Z5, Z0, Z0
+	VPADDD.BCST 28(CX), Z0, Z0
+	VPXORD      Z0, Z15, Z15
+	VPRORD      $0x10, Z15, Z15
+	VPADDD      Z15, Z10, Z10
+	VPXORD      Z10, Z5, Z5
+	VPRORD      $0x0c, Z5, Z5
+	VPADDD      Z5, Z0, Z0
+	VPADDD.BCST 8(CX), Z0, Z0
+	VPXORD      Z0, Z15, Z15
+	VPRORD      $0x08, Z15, Z15
+	VPADDD      Z15, Z10, Z10
+	VPXORD      Z10, Z5, Z5
+	VPRORD      $0x07, Z5, Z5
+	VPADDD      Z6, Z1, Z1
+	VPADDD.BCST 20(CX), Z1, Z1
+	VPXORD      Z1, Z12, Z12
+	VPRORD      $0x10, Z12, Z12
+	VPADDD      Z12, Z11, Z11
+	VPXORD      Z11, Z6, Z6
+	VPRORD      $0x0c, Z6, Z6
+	VPADDD      Z6, Z1, Z1
+	VPADDD.BCST 12(CX), Z1, Z1
+	VPXORD      Z1, Z12, Z12
+	VPRORD      $0x08, Z12, Z12
+	VPADDD      Z12, Z11, Z11
+	VPXORD      Z11, Z6, Z6
+	VPRORD      $0x07, Z6, Z6
+	VPADDD      Z7, Z2, Z2
+	VPADDD.BCST (CX), Z2, Z2
+	VPXORD      Z2, Z13, Z13
+	VPRORD      $0x10, Z13, Z13
+	VPADDD      Z13, Z8, Z8
+	VPXORD      Z8, Z7, Z7
+	VPRORD      $0x0c, Z7, Z7
+	VPADDD      Z7, Z2, Z2
+	VPADDD.BCST 4(CX), Z2, Z2
+	VPXORD      Z2, Z13, Z13
+	VPRORD      $0x08, Z13, Z13
+	VPADDD      Z13, Z8, Z8
+	VPXORD      Z8, Z7, Z7
+	VPRORD      $0x07, Z7, Z7
+	VPADDD      Z4, Z3, Z3
+	VPADDD.BCST 24(CX), Z3, Z3
+	VPXORD      Z3, Z14, Z14
+	VPRORD      $0x10, Z14, Z14
+	VPADDD      Z14, Z9, Z9
+	VPXORD      Z9, Z4, Z4
+	VPRORD      $0x0c, Z4, Z4
+	VPADDD      Z4, Z3, Z3
+	VPADDD.BCST 16(CX), Z3, Z3
+	VPXORD      Z3, Z14, Z14
+	VPRORD      $0x08, Z14, Z14
+	VPADDD      Z14, Z9, Z9
+	VPXORD      Z9, Z4, Z4
+	VPRORD      $0x07, Z4, Z4
+
+	// Round 6
+	VPADDD      Z4, Z0, Z0
+	VPADDD.BCST 36(CX), Z0, Z0
+	VPXORD      Z0, Z12, Z12
+	VPRORD      $0x10, Z12, Z12
+	VPADDD      Z12, Z8, Z8
+	VPXORD      Z8, Z4, Z4
+	VPRORD      $0x0c, Z4, Z4
+	VPADDD      Z4, Z0, Z0
+	VPADDD.BCST 56(CX), Z0, Z0
+	VPXORD      Z0, Z12, Z12
+	VPRORD      $0x08, Z12, Z12
+	VPADDD      Z12, Z8, Z8
+	VPXORD      Z8, Z4, Z4
+	VPRORD      $0x07, Z4, Z4
+	VPADDD      Z5, Z1, Z1
+	VPADDD.BCST 44(CX), Z1, Z1
+	VPXORD      Z1, Z13, Z13
+	VPRORD      $0x10, Z13, Z13
+	VPADDD      Z13, Z9, Z9
+	VPXORD      Z9, Z5, Z5
+	VPRORD      $0x0c, Z5, Z5
+	VPADDD      Z5, Z1, Z1
+	VPADDD.BCST 20(CX), Z1, Z1
+	VPXORD      Z1, Z13, Z13
+	VPRORD      $0x08, Z13, Z13
+	VPADDD      Z13, Z9, Z9
+	VPXORD      Z9, Z5, Z5
+	VPRORD      $0x07, Z5, Z5
+	VPADDD      Z6, Z2, Z2
+	VPADDD.BCST 32(CX), Z2, Z2
+	VPXORD      Z2, Z14, Z14
+	VPRORD      $0x10, Z14, Z14
+	VPADDD      Z14, Z10, Z10
+	VPXORD      Z10, Z6, Z6
+	VPRORD      $0x0c, Z6, Z6
+	VPADDD      Z6, Z2, Z2
+	VPADDD.BCST 48(CX), Z2, Z2
+	VPXORD      Z2, Z14, Z14
+	VPRORD      $0x08, Z14, Z14
+	VPADDD      Z14, Z10, Z10
+	VPXORD      Z10, Z6, Z6
+	VPRORD      $0x07, Z6, Z6
+	VPADDD      Z7, Z3, Z3
+	VPADDD.BCST 60(CX), Z3, Z3
+	VPXORD      Z3, Z15, Z15
+	VPRORD      $0x10, Z15, Z15
+	VPADDD      Z15, Z11, Z11
+	VPXORD      Z11, Z7, Z7
+	VPRORD      $0x0c, Z7, Z7
+	VPADDD      Z7, Z3, Z3
+	VPADDD.BCST 4(CX), Z3, Z3
+	VPXORD      Z3, Z15, Z15
+	VPRORD      $0x08, Z15, Z15
+	VPADDD      Z15, Z11, Z11
+	VPXORD      Z11, Z7, Z7
+	VPRORD      $0x07, Z7, Z7
+	VPADDD      Z5, Z0, Z0
+	VPADDD.BCST 52(CX), Z0, Z0
+	VPXORD      Z0, Z15, Z15
+	VPRORD      $0x10, Z15, Z15
+	VPADDD      Z15, Z10, Z10
+	VPXORD      Z10, Z5, Z5
+	VPRORD      $0x0c, Z5, Z5
+	VPADDD      Z5, Z0, Z0
+	VPADDD.BCST 12(CX), Z0, Z0
+	VPXORD      Z0, Z15, Z15
+	VPRORD      $0x08, Z15, Z15
+	VPADDD      Z15, Z10, Z10
+	VPXORD      Z10, Z5, Z5
+	VPRORD      $0x07, Z5, Z5
+	VPADDD      Z6, Z1, Z1
+	VPADDD.BCST (CX), Z1, Z1
+	VPXORD      Z1, Z12, Z12
+	VPRORD      $0x10, Z12, Z12
+	VPADDD      Z12, Z11, Z11
+	VPXORD      Z11, Z6, Z6
+	VPRORD      $0x0c, Z6, Z6
+	VPADDD      Z6, Z1, Z1
+	VPADDD.BCST 40(CX), Z1, Z1
+	VPXORD      Z1, Z12, Z12
+	VPRORD      $0x08, Z12, Z12
+	VPADDD      Z12, Z11, Z11
+	VPXORD      Z11, Z6, Z6
+	VPRORD      $0x07, Z6, Z6
+	VPADDD      Z7, Z2, Z2
+	VPADDD.BCST 8(CX), Z2, Z2
+	VPXORD      Z2, Z13, Z13
+	VPRORD      $0x10, Z13, Z13
+	VPADDD      Z13, Z8, Z8
+	VPXORD      Z8, Z7, Z7
+	VPRORD      $0x0c, Z7, Z7
+	VPADDD      Z7, Z2, Z2
+	VPADDD.BCST 24(CX), Z2, Z2
+	VPXORD      Z2, Z13, Z13
+	VPRORD      $0x08, Z13, Z13
+	VPADDD      Z13, Z8, Z8
+	VPXORD      Z8, Z7, Z7
+	VPRORD      $0x07, Z7, Z7
+	VPADDD      Z4, Z3, Z3
+	VPADDD.BCST 16(CX), Z3, Z3
+	VPXORD      Z3, Z14, Z14
+	VPRORD      $0x10, Z14, Z14
+	VPADDD      Z14, Z9, Z9
+	VPXORD      Z9, Z4, Z4
+	VPRORD      $0x0c, Z4, Z4
+	VPADDD      Z4, Z3, Z3
+	VPADDD.BCST 28(CX), Z3, Z3
+	VPXORD      Z3, Z14, Z14
+	VPRORD      $0x08, Z14, Z14
+	VPADDD      Z14, Z9, Z9
+	VPXORD      Z9, Z4, Z4
+	VPRORD      $0x07, Z4, Z4
+
+	// Round 7
+	VPADDD      Z4, Z0, Z0
+	VPADDD.BCST 44(CX), Z0, Z0
+	VPXORD      Z0, Z12, Z12
+	VPRORD      $0x10, Z12, Z12
+	VPADDD      Z12, Z8, Z8
+	VPXORD      Z8, Z4, Z4
+	VPRORD      $0x0c, Z4, Z4
+	VPADDD      Z4, Z0, Z0
+	VPADDD.BCST 60(CX), Z0, Z0
+	VPXORD      Z0, Z12, Z12
+	VPRORD      $0x08, Z12, Z12
+	VPADDD      Z12, Z8, Z8
+	VPXORD      Z8, Z4, Z4
+	VPRORD      $0x07, Z4, Z4
+	VPADDD      Z5, Z1, Z1
+	VPADDD.BCST 20(CX), Z1, Z1
+	VPXORD      Z1, Z13, Z13
+	VPRORD      $0x10, Z13, Z13
+	VPADDD      Z13, Z9, Z9
+	VPXORD      Z9, Z5, Z5
+	VPRORD      $0x0c, Z5, Z5
+	VPADDD      Z5, Z1, Z1
+	VPADDD.BCST (CX), Z1, Z1
+	VPXORD      Z1, Z13, Z13
+	VPRORD      $0x08, Z13, Z13
+	VPADDD      Z13, Z9, Z9
+	VPXORD      Z9, Z5, Z5
+	VPRORD      $0x07, Z5, Z5
+	VPADDD      Z6, Z2, Z2
+	VPADDD.BCST 4(CX), Z2, Z2
+	VPXORD      Z2, Z14, Z14
+	VPRORD      $0x10, Z14, Z14
+	VPADDD      Z14, Z10, Z10
+	VPXORD      Z10, Z6, Z6
+	VPRORD      $0x0c, Z6, Z6
+	VPADDD      Z6, Z2, Z2
+	VPADDD.BCST 36(CX), Z2, Z2
+	VPXORD      Z2, Z14, Z14
+	VPRORD      $0x08, Z14, Z14
+	VPADDD      Z14, Z10, Z10
+	VPXORD      Z10, Z6, Z6
+	VPRORD      $0x07, Z6, Z6
+	VPADDD      Z7, Z3, Z3
+	VPADDD.BCST 32(CX), Z3, Z3
+	VPXORD      Z3, Z15, Z15
+	VPRORD      $0x10, Z15, Z15
+	VPADDD      Z15, Z11, Z11
+	VPXORD      Z11, Z7, Z7
+	VPRORD      $0x0c, Z7, Z7
+	VPADDD      Z7, Z3, Z3
+	VPADDD.BCST 24(CX), Z3, Z3
+	VPXORD      Z3, Z15, Z15
+	VPRORD      $0x08, Z15, Z15
+	VPADDD      Z15, Z11, Z11
+	VPXORD      Z11, Z7, Z7
+	VPRORD      $0x07, Z7, Z7
+	VPADDD      Z5, Z0, Z0
+	VPADDD.BCST 56(CX), Z0, Z0
+	VPXORD      Z0, Z15, Z15
+	VPRORD      $0x10, Z15, Z15
+	VPADDD      Z15, Z10, Z10
+	VPXORD      Z10, Z5, Z5
+	VPRORD      $0x0c, Z5, Z5
+	VPADDD      Z5, Z0, Z0
+	VPADDD.BCST 40(CX), Z0, Z0
+	VPXORD      Z0, Z15, Z15
+	VPRORD      $0x08, Z15, Z15
+	VPADDD      Z15, Z10, Z10
+	VPXORD      Z10, Z5, Z5
+	VPRORD      $0x07, Z5, Z5
+	VPADDD      Z6, Z1, Z1
+	VPADDD.BCST 8(CX), Z1, Z1
+	VPXORD      Z1, Z12, Z12
+	VPRORD      $0x10, Z12, Z12
+	VPADDD      Z12, Z11, Z11
+	VPXORD      Z11, Z6, Z6
+	VPRORD      $0x0c, Z6, Z6
+	VPADDD      Z6, Z1, Z1
+	VPADDD.BCST 48(CX), Z1, Z1
+	VPXORD      Z1, Z12, Z12
+	VPRORD      $0x08, Z12, Z12
+	VPADDD      Z12, Z11, Z11
+	VPXORD      Z11, Z6, Z6
+	VPRORD      $0x07, Z6, Z6
+	VPADDD      Z7, Z2, Z2
+	VPADDD.BCST 12(CX), Z2, Z2
+	VPXORD      Z2, Z13, Z13
+	VPRORD      $0x10, Z13, Z13
+	VPADDD      Z13, Z8, Z8
+	VPXORD      Z8, Z7, Z7
+	VPRORD      $0x0c, Z7, Z7
+	VPADDD      Z7, Z2, Z2
+	VPADDD.BCST 16(CX), Z2, Z2
+	VPXORD      Z2, Z13, Z13
+	VPRORD      $0x08, Z13, Z13
+	VPADDD      Z13, Z8, Z8
+	VPXORD      Z8, Z7, Z7
+	VPRORD      $0x07, Z7, Z7
+	VPADDD      Z4, Z3, Z3
+	VPADDD.BCST 28(CX), Z3, Z3
+	VPXORD      Z3, Z14, Z14
+	VPRORD      $0x10, Z14, Z14
+	VPADDD      Z14, Z9, Z9
+	VPXORD      Z9, Z4, Z4
+	VPRORD      $0x0c, Z4, Z4
+	VPADDD      Z4, Z3, Z3
+	VPADDD.BCST 52(CX), Z3, Z3
+	VPXORD      Z3, Z14, Z14
+	VPRORD      $0x08, Z14, Z14
+	VPADDD      Z14, Z9, Z9
+	VPXORD      Z9, Z4, Z4
+	VPRORD      $0x07, Z4, Z4
+
+	// Finalize the output words
+	VPXORD      Z8, Z0, Z0
+	VPXORD.BCST (AX), Z8, Z8
+	VPXORD      Z9, Z1, Z1
+	VPXORD.BCST 4(AX), Z9, Z9
+	VPXORD      Z10, Z2, Z2
+	VPXORD.BCST 8(AX), Z10, Z10
+	VPXORD      Z11, Z3, Z3
+	VPXORD.BCST 12(AX), Z11, Z11
+	VPXORD      Z12, Z4, Z4
+	VPXORD.BCST 16(AX), Z12, Z12
+	VPXORD      Z13, Z5, Z5
+	VPXORD.BCST 20(AX), Z13, Z13
+	VPXORD      Z14, Z6, Z6
+	VPXORD.BCST 24(AX), Z14, Z14
+	VPXORD      Z15, Z7, Z7
+	VPXORD.BCST 28(AX), Z15, Z15
+
+	// Transpose the words into blocks
+	VPUNPCKLDQ  Z1, Z0, Z16
+	VPUNPCKHDQ  Z1, Z0, Z17
+	VPUNPCKLDQ  Z3, Z2, Z18
+	VPUNPCKHDQ  Z3, Z2, Z19
+	VPUNPCKLDQ  Z5, Z4, Z20
+	VPUNPCKHDQ  Z5, Z4, Z21
+	VPUNPCKLDQ  Z7, Z6, Z22
+	VPUNPCKHDQ  Z7, Z6, Z23
+	VPUNPCKLDQ  Z9, Z8, Z24
+	VPUNPCKHDQ  Z9, Z8, Z25
+	VPUNPCKLDQ  Z11, Z10, Z26
+	VPUNPCKHDQ  Z11, Z10, Z27
+	VPUNPCKLDQ  Z13, Z12, Z28
+	VPUNPCKHDQ  Z13, Z12, Z29
+	VPUNPCKLDQ  Z15, Z14, Z30
+	VPUNPCKHDQ  Z15, Z14, Z31
+	VPUNPCKLQDQ Z18, Z16, Z0
+	VPUNPCKHQDQ Z18, Z16, Z1
+	VPUNPCKLQDQ Z19, Z17, Z2
+	VPUNPCKHQDQ Z19, Z17, Z3
+	VPUNPCKLQDQ Z22, Z20, Z4
+	VPUNPCKHQDQ Z22, Z20, Z5
+	VPUNPCKLQDQ Z23, Z21, Z6
+	VPUNPCKHQDQ Z23, Z21, Z7
+	VPUNPCKLQDQ Z26, Z24, Z8
+	VPUNPCKHQDQ Z26, Z24, Z9
+	VPUNPCKLQDQ Z27, Z25, Z10
+	VPUNPCKHQDQ Z27, Z25, Z11
+	VPUNPCKLQDQ Z30, Z28, Z12
+	VPUNPCKHQDQ Z30, Z28, Z13
+	VPUNPCKLQDQ Z31, Z29, Z14
+	VPUNPCKHQDQ Z31, Z29, Z15
+	VSHUFI32X4  $0x44, Z4, Z0, Z16
+	VSHUFI32X4  $0xee, Z4, Z0, Z17
+	VSHUFI32X4  $0x44, Z12, Z8, Z18
+	VSHUFI32X4  $0xee, Z12, Z8, Z19
+	VSHUFI32X4  $0x88, Z18, Z16, Z20
+	VSHUFI32X4  $0xdd, Z18, Z16, Z21
+	VSHUFI32X4  $0x88, Z19, Z17, Z22
+	VSHUFI32X4  $0xdd, Z19, Z17, Z23
+	VMOVDQU32   Z20, (DI)
+	VMOVDQU32   Z21, 256(DI)
+	VMOVDQU32   Z22, 512(DI)
+	VMOVDQU32   Z23, 768(DI)
+	VSHUFI32X4  $0x44, Z5, Z1, Z16
+	VSHUFI32X4  $0xee, Z5, Z1, Z17
+	VSHUFI32X4  $0x44, Z13, Z9, Z18
+	VSHUFI32X4  $0xee, Z13, Z9, Z19
+	VSHUFI32X4  $0x88, Z18, Z16, Z20
+	VSHUFI32X4  $0xdd, Z18, Z16, Z21
+	VSHUFI32X4  $0x88, Z19, Z17, Z22
+	VSHUFI32X4  $0xdd, Z19, Z17, Z23
+	VMOVDQU32   Z20, 64(DI)
+	VMOVDQU32   Z21, 320(DI)
+	VMOVDQU32   Z22, 576(DI)
+	VMOVDQU32   Z23, 832(DI)
+	VSHUFI32X4  $0x44, Z6, Z2, Z16
+	VSHUFI32X4  $0xee, Z6, Z2, Z17
+	VSHUFI32X4  $0x44, Z14, Z10, Z18
+	VSHUFI32X4  $0xee, Z14, Z10, Z19
+	VSHUFI32X4  $0x88, Z18, Z16, Z20
+	VSHUFI32X4  $0xdd, Z18, Z16, Z21
+	VSHUFI32X4  $0x88, Z19, Z17, Z22
+	VSHUFI32X4  $0xdd, Z19, Z17, Z23
+	VMOVDQU32   Z20, 128(DI)
+	VMOVDQU32   Z21, 384(DI)
+	VMOVDQU32   Z22, 640(DI)
+	VMOVDQU32   Z23, 896(DI)
+	VSHUFI32X4  $0x44, Z7, Z3, Z16
+	VSHUFI32X4  $0xee, Z7, Z3, Z17
+	VSHUFI32X4  $0x44, Z15, Z11, Z18
+	VSHUFI32X4  $0xee, Z15, Z11, Z19
+	VSHUFI32X4  $0x88, Z18, Z16, Z20
+	VSHUFI32X4  $0xdd, Z18, Z16, Z21
+	VSHUFI32X4  $0x88, Z19, Z17, Z22
+	VSHUFI32X4  $0xdd, Z19, Z17, Z23
+	VMOVDQU32   Z20, 192(DI)
+	VMOVDQU32   Z21, 448(DI)
+	VMOVDQU32   Z22, 704(DI)
+	VMOVDQU32   Z23, 960(DI)
+	VZEROUPPER
+	RET
@@ -74,3 +74,33 @@ func TestHashP(t *testing.T) {
 		}
 	}
 }
+
+func TestHashX(t *testing.T) {
+	if !consts.HasAVX512 {
+		t.SkipNow()
+	}
+
+	var chain [8]uint32
+	var block [16]uint32
+
+	for n := 0; n < 100; n++ {
+		var o1, o2 [1024]byte
+
+		for i := range &chain {
+			chain[i] = pcg.Uint32()
+		}
+		for i := range &block {
+			block[i] = pcg.Uint32()
+		}
+		ctr, blen, flags := pcg.Uint64(), pcg.Uint32()%65, pcg.Uint32()
+		if n%10 == 0 {
+			// the counter carries into the high word in the middle
+			ctr = 1<<32 - uint64(n/10)
+		}
+
+		hash_avx512.HashX(&chain, &block, ctr, blen, flags, &o1)
+		hash_pure.HashX(&chain, &block, ctr, blen, flags, &o2)
+
+		assert.Equal(t, o1, o2)
+	}
+}
@@ -0,0 +1,31 @@
+package hash_pure
+
+import (
+	"github.com/zeebo/blake3/internal/alg/compress"
+	"github.com/zeebo/blake3/internal/consts"
+	"github.com/zeebo/blake3/internal/utils"
+)
+
+func HashM(input *[8192]byte, blocks, counter uint64, key *[64]uint32, blen, flags *[16][8]uint32, out *[64]uint32) {
+	var tmp [16]uint32
+	var block [16]uint32
+
+	for i := 0; i < 8; i++ {
+		var chain [8]uint32
+		for j := range chain {
+			chain[j] = key[i+8*j]
+		}
+
+		for n := uint64(0); n < blocks && n < 16; n++ {
+			utils.BytesToWords((*[64]uint8)(input[consts.ChunkLen*i+consts.BlockLen*int(n):]), &block)
+			compress.Compress(&chain, &block, counter, blen[n][i], flags[n][i], &tmp)
+			chain = *(*[8]uint32)(tmp[0:8])
+
+			if flags[n][i]&(consts.Flag_ChunkEnd|consts.Flag_Parent) != 0 {
+				for j := range chain {
+					out[i+8*j] = chain[j]
+				}
+			}
+		}
+	}
+}
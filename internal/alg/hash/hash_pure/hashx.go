@@ -0,0 +1,18 @@
+package hash_pure
+
+import (
+	"encoding/binary"
+
+	"github.com/zeebo/blake3/internal/alg/compress"
+)
+
+func HashX(chain *[8]uint32, block *[16]uint32, counter uint64, blen uint32, flags uint32, out *[1024]byte) {
+	var tmp [16]uint32
+
+	for i := 0; i < 16; i++ {
+		compress.Compress(chain, block, counter+uint64(i), blen, flags, &tmp)
+		for j, w := range tmp {
+			binary.LittleEndian.PutUint32(out[64*i+4*j:], w)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+// Package digest lets packages in this module create a blake3.Digest from
+// the input to a root compression without depending on its binary encoding.
+package digest
+
+// New returns a *blake3.Digest positioned at the start of the output stream
+// of the root node with the given chain, block, block length and flags. The
+// root flag is added by New. It is set by the blake3 package when it is
+// initialized, so it is available to any package that imports blake3.
+var New func(chain *[8]uint32, block *[16]uint32, blen, flags uint32) interface{}
@@ -0,0 +1,90 @@
+package blake3
+
+import (
+	"crypto/subtle"
+	"errors"
+)
+
+// MinMACSize is the smallest tag size accepted by NewMAC. Shorter tags are
+// too easy to forge by guessing.
+const MinMACSize = 16
+
+// MAC computes and verifies message authentication codes with keyed BLAKE3.
+// Tags are read from the Digest of the keyed hash, so a truncated tag is a
+// prefix of the full 32 byte tag for the same key and message.
+//
+// MAC implements hash.Hash, with Sum appending the tag.
+type MAC struct {
+	h *Hasher
+}
+
+// NewMAC returns a MAC that uses the 32 byte key and produces tags of size
+// bytes. It returns an error if the key is not 32 bytes or if size is less
+// than MinMACSize.
+func NewMAC(key []byte, size int) (*MAC, error) {
+	if size < MinMACSize {
+		return nil, errors.New("tag size too small")
+	}
+	h, err := NewKeyed(key)
+	if err != nil {
+		return nil, err
+	}
+	h.size = size
+	return &MAC{h: h}, nil
+}
+
+// Write implements part of the hash.Hash interface. It adds more of the
+// message to the MAC and never returns an error.
+func (m *MAC) Write(p []byte) (int, error) { return m.h.Write(p) }
+
+// WriteString is like Write but specialized to strings to avoid allocations.
+func (m *MAC) WriteString(p string) (int, error) { return m.h.WriteString(p) }
+
+// Reset implements part of the hash.Hash interface. It discards the message
+// written so far but keeps the key.
+func (m *MAC) Reset() { m.h.Reset() }
+
+// Size implements part of the hash.Hash interface. It returns the tag size.
+func (m *MAC) Size() int { return m.h.size }
+
+// BlockSize implements part of the hash.Hash interface.
+func (m *MAC) BlockSize() int { return m.h.BlockSize() }
+
+// Sum implements part of the hash.Hash interface. It appends the tag for the
+// message written so far to b and returns it. It does not change the state
+// of the MAC.
+func (m *MAC) Sum(b []byte) []byte {
+	var d Digest
+	m.h.h.finalizeDigest(&d)
+
+	n := len(b)
+	b = append(b, make([]byte, m.h.size)...)
+	_, _ = d.Read(b[n:])
+	return b
+}
+
+// Verify reports whether tag is the correct tag for the message written so
+// far. The comparison is constant time, and a tag with a length other than
+// Size is always rejected.
+func (m *MAC) Verify(tag []byte) bool {
+	if len(tag) != m.h.size {
+		return false
+	}
+
+	var d Digest
+	m.h.h.finalizeDigest(&d)
+
+	// compare a block at a time so that long tags do not allocate
+	var buf [64]byte
+	ok := 1
+	for len(tag) > 0 {
+		n := len(tag)
+		if n > len(buf) {
+			n = len(buf)
+		}
+		_, _ = d.Read(buf[:n])
+		ok &= subtle.ConstantTimeCompare(buf[:n], tag[:n])
+		tag = tag[n:]
+	}
+	return ok == 1
+}
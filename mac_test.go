@@ -0,0 +1,77 @@
+package blake3
+
+import (
+	"bytes"
+	"hash"
+	"testing"
+
+	"github.com/zeebo/assert"
+)
+
+var _ hash.Hash = (*MAC)(nil)
+
+func TestMAC(t *testing.T) {
+	key := bytes.Repeat([]byte("1"), 32)
+
+	keyed, err := NewKeyed(key)
+	assert.NoError(t, err)
+	_, _ = keyed.WriteString("some data")
+	full := make([]byte, 101)
+	_, _ = keyed.Digest().Read(full)
+
+	for _, size := range []int{16, 20, 32, 64, 65, 100} {
+		m, err := NewMAC(key, size)
+		assert.NoError(t, err)
+		assert.Equal(t, m.Size(), size)
+
+		_, _ = m.WriteString("some data")
+		tag := m.Sum([]byte("prefix"))
+		assert.Equal(t, string(tag[:6]), "prefix")
+		assert.DeepEqual(t, tag[6:], full[:size])
+
+		assert.That(t, m.Verify(tag[6:]))
+		assert.That(t, !m.Verify(tag[6:len(tag)-1]))
+		assert.That(t, !m.Verify(full[:size+1]))
+		assert.That(t, !m.Verify(nil))
+
+		for i := range tag[6:] {
+			bad := append([]byte(nil), tag[6:]...)
+			bad[i] ^= 1
+			assert.That(t, !m.Verify(bad))
+		}
+
+		m.Reset()
+		assert.That(t, !m.Verify(tag[6:]))
+		_, _ = m.Write([]byte("some data"))
+		assert.That(t, m.Verify(tag[6:]))
+	}
+
+	assert.DeepEqual(t, full[:32], []byte{
+		0x10, 0x7c, 0x6f, 0x88, 0x63, 0x83, 0x56, 0xd7, 0x3c, 0xdb, 0x80, 0xf4, 0xd5, 0x6f, 0xfe, 0x50,
+		0xab, 0xcb, 0xd9, 0x66, 0x4a, 0x80, 0xc8, 0xab, 0x2b, 0x83, 0xb1, 0xf9, 0x46, 0xeb, 0xab, 0xa1,
+	})
+}
+
+func TestMAC_Errors(t *testing.T) {
+	_, err := NewMAC(make([]byte, 32), MinMACSize-1)
+	assert.Error(t, err)
+
+	_, err = NewMAC(make([]byte, 32), 0)
+	assert.Error(t, err)
+
+	_, err = NewMAC(make([]byte, 31), 32)
+	assert.Error(t, err)
+}
+
+func TestMAC_Allocs(t *testing.T) {
+	m, err := NewMAC(make([]byte, 32), 32)
+	assert.NoError(t, err)
+	_, _ = m.WriteString("some data")
+	tag := m.Sum(nil)
+
+	assert.Equal(t, testing.AllocsPerRun(100, func() {
+		if !m.Verify(tag) {
+			panic("bad tag")
+		}
+	}), 0.0)
+}
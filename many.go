@@ -0,0 +1,129 @@
+package blake3
+
+import (
+	"encoding/binary"
+
+	"github.com/zeebo/blake3/internal/alg"
+	"github.com/zeebo/blake3/internal/consts"
+	"github.com/zeebo/blake3/internal/utils"
+)
+
+// SumMany sets outs[i] to the Sum256 of inputs[i] for every input. Inputs of
+// at most 1024 bytes are hashed eight at a time, one per SIMD lane where
+// supported, which is much faster than calling Sum256 for each of them.
+// Longer inputs are hashed one at a time. It panics if outs is shorter than
+// inputs.
+func SumMany(inputs [][]byte, outs [][32]byte) {
+	if len(outs) < len(inputs) {
+		panic("blake3: SumMany outs shorter than inputs")
+	}
+
+	var l lanes
+	for i, input := range inputs {
+		if len(input) > consts.ChunkLen {
+			outs[i] = Sum256(input)
+			continue
+		}
+		l.add(input, &consts.IV, 0, &outs[i])
+	}
+	l.flush()
+}
+
+// KeyedSumMany sets out[i] to the first 256 bits of the digest of msgs[i]
+// keyed with keys[i], the same as a Hasher returned by NewKeyed. Like
+// SumMany, messages of at most 1024 bytes are hashed eight at a time, each
+// lane with its own key, and no Hasher is allocated. It panics if keys or out
+// are shorter than msgs.
+func KeyedSumMany(keys [][32]byte, msgs [][]byte, out [][32]byte) {
+	if len(keys) < len(msgs) || len(out) < len(msgs) {
+		panic("blake3: KeyedSumMany keys or out shorter than msgs")
+	}
+
+	var l lanes
+	var key [8]uint32
+	for i, msg := range msgs {
+		utils.KeyFromBytes(keys[i][:], &key)
+		if len(msg) > consts.ChunkLen {
+			sumLarge(msg, consts.Flag_Keyed, &key, out[i][:])
+			continue
+		}
+		l.add(msg, &key, consts.Flag_Keyed, &out[i])
+	}
+	l.flush()
+}
+
+//
+// hashing independent messages in parallel lanes
+//
+
+// lanes hashes up to eight messages of at most one chunk at a time, one
+// message per lane of alg.HashM, each with its own key and flags.
+type lanes struct {
+	input  [8192]byte
+	key    chainVector
+	blen   [16][8]uint32
+	flags  [16][8]uint32
+	out    chainVector
+	blocks uint64
+	n      int
+	dst    [8]*[32]byte
+}
+
+// add queues the root hash of input into dst, flushing if every lane is in
+// use. The input must be at most one chunk.
+func (l *lanes) add(input []byte, key *[8]uint32, flags uint32, dst *[32]byte) {
+	i := l.n
+
+	blocks := (len(input) + consts.BlockLen - 1) / consts.BlockLen
+	if blocks == 0 {
+		blocks = 1
+	}
+
+	// the last block is always read in full, so zero its tail
+	lane := l.input[consts.ChunkLen*i : consts.ChunkLen*i+consts.BlockLen*blocks]
+	for j := copy(lane, input); j < len(lane); j++ {
+		lane[j] = 0
+	}
+
+	writeChain(key, &l.key, i)
+	for b := 0; b < blocks; b++ {
+		l.blen[b][i] = consts.BlockLen
+		l.flags[b][i] = flags
+	}
+	l.flags[0][i] |= consts.Flag_ChunkStart
+	l.blen[blocks-1][i] = uint32(len(input) - consts.BlockLen*(blocks-1))
+	l.flags[blocks-1][i] |= consts.Flag_ChunkEnd | consts.Flag_Root
+
+	if uint64(blocks) > l.blocks {
+		l.blocks = uint64(blocks)
+	}
+	l.dst[i] = dst
+	l.n++
+
+	if l.n == 8 {
+		l.flush()
+	}
+}
+
+// flush hashes the queued messages and writes their root hashes.
+func (l *lanes) flush() {
+	if l.n == 0 {
+		return
+	}
+
+	alg.HashM(&l.input, l.blocks, 0, &l.key, &l.blen, &l.flags, &l.out)
+
+	var chain [8]uint32
+	for i := 0; i < l.n; i++ {
+		readChain(&l.out, i, &chain)
+		for j, w := range chain {
+			binary.LittleEndian.PutUint32(l.dst[i][4*j:], w)
+		}
+	}
+
+	// unused lanes and blocks past the end of a message must not be stored
+	for b := uint64(0); b < l.blocks; b++ {
+		l.flags[b] = [8]uint32{}
+	}
+	l.blocks, l.n = 0, 0
+}
@@ -0,0 +1,84 @@
+package blake3
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/zeebo/assert"
+)
+
+func TestSumMany(t *testing.T) {
+	x := make([]byte, 4096)
+	for i := range x {
+		x[i] = byte(i) % 251
+	}
+
+	// every length up to a chunk in different lanes and batches, with a
+	// few longer inputs mixed in
+	var inputs [][]byte
+	for n := 0; n <= 1025; n++ {
+		inputs = append(inputs, x[n%7:n%7+n])
+		if n%100 == 0 {
+			inputs = append(inputs, x[:2048+n])
+		}
+	}
+
+	for _, count := range []int{0, 1, 7, 8, 9, len(inputs)} {
+		outs := make([][32]byte, count)
+		SumMany(inputs[:count], outs)
+		for i, out := range outs {
+			assert.Equal(t, out, Sum256(inputs[i]))
+		}
+	}
+}
+
+func TestKeyedSumMany(t *testing.T) {
+	x := make([]byte, 4096)
+	for i := range x {
+		x[i] = byte(i) % 251
+	}
+
+	var keys [][32]byte
+	var msgs [][]byte
+	for n := 0; n <= 1025; n += 3 {
+		var key [32]byte
+		for i := range key {
+			key[i] = byte(n + i)
+		}
+		keys = append(keys, key)
+		msgs = append(msgs, x[:n])
+		if n%100 == 0 {
+			keys = append(keys, key)
+			msgs = append(msgs, x[:2048+n])
+		}
+	}
+
+	out := make([][32]byte, len(msgs))
+	KeyedSumMany(keys, msgs, out)
+	for i := range msgs {
+		h, err := NewKeyed(keys[i][:])
+		assert.NoError(t, err)
+		_, _ = h.Write(msgs[i])
+		assert.DeepEqual(t, out[i][:], h.Sum(nil))
+	}
+}
+
+func BenchmarkSumMany(b *testing.B) {
+	for _, size := range []int{64, 256, 512, 1024} {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			inputs := make([][]byte, 64)
+			for i := range inputs {
+				inputs[i] = make([]byte, size)
+			}
+			outs := make([][32]byte, len(inputs))
+
+			b.SetBytes(int64(size * len(inputs)))
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				SumMany(inputs, outs)
+			}
+		})
+	}
+}
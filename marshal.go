@@ -0,0 +1,95 @@
+package blake3
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+
+	"github.com/zeebo/blake3/internal/consts"
+)
+
+const (
+	hasherMagic = "b3h\x01"
+
+	// hasherMarshaledSize is the size of the fixed fields of a marshaled
+	// hasher: the magic, size, flags, key, chunk counter and buffer length.
+	hasherMarshaledSize = len(hasherMagic) + 4 + 4 + 32 + 8 + 2
+)
+
+//
+// serialization of hasher state
+//
+
+// appendBinary appends the state of the hasher. Once every pending pair is
+// merged, the occupied levels of the stack are exactly the set bits of the
+// chunk counter, so only those levels are written.
+func (a *hasher) appendBinary(b []byte, size int) []byte {
+	stack := a.stack
+	for stack.bufn > 0 {
+		stack.flush(a.flags, &a.key)
+	}
+
+	b = append(b, hasherMagic...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(size))
+	b = binary.LittleEndian.AppendUint32(b, a.flags)
+	for _, w := range a.key {
+		b = binary.LittleEndian.AppendUint32(b, w)
+	}
+	b = binary.LittleEndian.AppendUint64(b, a.chunks)
+	b = binary.LittleEndian.AppendUint16(b, uint16(a.len))
+
+	for occ := stack.occ; occ != 0; occ &= occ - 1 {
+		for _, w := range stack.stack[bits.TrailingZeros64(occ)%64] {
+			b = binary.LittleEndian.AppendUint32(b, w)
+		}
+	}
+
+	return append(b, a.buf[:a.len]...)
+}
+
+func (a *hasher) unmarshalBinary(b []byte) (size int, err error) {
+	if len(b) < hasherMarshaledSize || string(b[:len(hasherMagic)]) != hasherMagic {
+		return 0, errors.New("invalid hash state identifier")
+	}
+	b = b[len(hasherMagic):]
+
+	size = int(binary.LittleEndian.Uint32(b[0:4]))
+	flags := binary.LittleEndian.Uint32(b[4:8])
+	var key [8]uint32
+	for i := range key {
+		key[i] = binary.LittleEndian.Uint32(b[8+4*i:])
+	}
+	chunks := binary.LittleEndian.Uint64(b[40:48])
+	blen := uint64(binary.LittleEndian.Uint16(b[48:50]))
+	b = b[50:]
+
+	switch flags {
+	case 0, consts.Flag_Keyed, consts.Flag_DeriveKeyContext, consts.Flag_DeriveKeyMaterial:
+	default:
+		return 0, errors.New("invalid hash state flags")
+	}
+	if size <= 0 || blen > 8192 || (chunks > 0 && blen == 0) {
+		return 0, errors.New("invalid hash state")
+	}
+	// only a single chunk can follow a group that does not end on a boundary
+	if chunks%8 != 0 && (chunks%8 != 7 || blen != consts.ChunkLen) {
+		return 0, errors.New("invalid hash state")
+	}
+	if len(b) != 32*bits.OnesCount64(chunks)+int(blen) {
+		return 0, errors.New("invalid hash state size")
+	}
+
+	a.reset()
+	a.flags, a.key, a.chunks, a.len = flags, key, chunks, blen
+	a.stack.occ = chunks
+	for occ := chunks; occ != 0; occ &= occ - 1 {
+		lvl := &a.stack.stack[bits.TrailingZeros64(occ)%64]
+		for i := range lvl {
+			lvl[i] = binary.LittleEndian.Uint32(b[4*i:])
+		}
+		b = b[32:]
+	}
+	copy(a.buf[:], b)
+
+	return size, nil
+}
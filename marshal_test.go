@@ -0,0 +1,143 @@
+package blake3
+
+import (
+	"encoding"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/zeebo/assert"
+)
+
+var _ encoding.BinaryMarshaler = (*Hasher)(nil)
+var _ encoding.BinaryUnmarshaler = (*Hasher)(nil)
+
+func TestHasher_MarshalBinary(t *testing.T) {
+	x := make([]byte, 1<<18+100)
+	for i := range x {
+		x[i] = byte(i) % 251
+	}
+
+	news := map[string]func() *Hasher{
+		"New": New,
+		"Keyed": func() *Hasher {
+			h, _ := NewKeyed([]byte(testVectorKey))
+			return h
+		},
+		"DeriveKey": func() *Hasher { return NewDeriveKey(testVectorContext) },
+	}
+
+	for name, newh := range news {
+		t.Run(name, func(t *testing.T) {
+			for _, split := range []int{0, 1, 1024, 8191, 8192, 8193, 5*8192 + 17, 1 << 18} {
+				h1 := newh()
+				_, _ = h1.Write(x[:split])
+
+				state, err := h1.MarshalBinary()
+				assert.NoError(t, err)
+
+				// unmarshal into a hasher with unrelated state
+				h2 := New()
+				_, _ = h2.Write(x)
+				assert.NoError(t, h2.UnmarshalBinary(state))
+				assert.Equal(t, hex.EncodeToString(h1.Sum(nil)), hex.EncodeToString(h2.Sum(nil)))
+
+				_, _ = h1.Write(x[split:])
+				_, _ = h2.Write(x[split:])
+				assert.Equal(t, hex.EncodeToString(h1.Sum(nil)), hex.EncodeToString(h2.Sum(nil)))
+			}
+		})
+	}
+}
+
+func TestHasher_MarshalBinary_Compact(t *testing.T) {
+	h := New()
+
+	state, err := h.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, len(state), hasherMarshaledSize)
+
+	// 25 chunks leave 24 of them on two levels of the stack and buffer the
+	// last one
+	_, _ = h.Write(make([]byte, 3*8192+1024))
+
+	state, err = h.AppendBinary([]byte("prefix"))
+	assert.NoError(t, err)
+	assert.Equal(t, string(state[:6]), "prefix")
+	assert.Equal(t, len(state), 6+hasherMarshaledSize+2*32+1024)
+}
+
+func TestHasher_UnmarshalBinary_Errors(t *testing.T) {
+	h := New()
+	_, _ = h.Write(make([]byte, 10000))
+	state, err := h.MarshalBinary()
+	assert.NoError(t, err)
+
+	assert.Error(t, h.UnmarshalBinary(nil))
+	assert.Error(t, h.UnmarshalBinary(state[:len(state)-1]))
+	assert.Error(t, h.UnmarshalBinary(append(state, 0)))
+	assert.Error(t, h.UnmarshalBinary(append([]byte("b3h\x02"), state[4:]...)))
+
+	bad := append([]byte(nil), state...)
+	bad[8] = 0xff // flags
+	assert.Error(t, h.UnmarshalBinary(bad))
+
+	bad = append([]byte(nil), state...)
+	bad[44] = 4 // chunks not on a group boundary
+	assert.Error(t, h.UnmarshalBinary(bad))
+
+	// a failed unmarshal leaves the hasher untouched
+	_, _ = h.Write(make([]byte, 10))
+	exp := New()
+	_, _ = exp.Write(make([]byte, 10010))
+	assert.Equal(t, hex.EncodeToString(h.Sum(nil)), hex.EncodeToString(exp.Sum(nil)))
+}
+
+var _ encoding.BinaryMarshaler = (*Digest)(nil)
+var _ encoding.BinaryUnmarshaler = (*Digest)(nil)
+
+func TestDigest_MarshalBinary(t *testing.T) {
+	for _, input := range []int{0, 100, 1025, 10000} {
+		h := New()
+		_, _ = h.Write(make([]byte, input))
+
+		exp := make([]byte, 1024)
+		_, _ = h.Digest().Read(exp)
+
+		for _, pos := range []int{0, 1, 63, 64, 65, 500, 1000} {
+			d := h.Digest()
+			_, _ = d.Read(make([]byte, pos))
+
+			state, err := d.MarshalBinary()
+			assert.NoError(t, err)
+			assert.Equal(t, len(state), digestMarshaledSize)
+
+			var r Digest
+			assert.NoError(t, r.UnmarshalBinary(state))
+
+			got := make([]byte, len(exp)-pos)
+			_, _ = r.Read(got)
+			assert.Equal(t, hex.EncodeToString(got), hex.EncodeToString(exp[pos:]))
+
+			off, err := r.Seek(0, io.SeekCurrent)
+			assert.NoError(t, err)
+			assert.Equal(t, off, int64(len(exp)))
+		}
+	}
+}
+
+func TestDigest_UnmarshalBinary_Errors(t *testing.T) {
+	d := New().Digest()
+	state, err := d.MarshalBinary()
+	assert.NoError(t, err)
+
+	var r Digest
+	assert.Error(t, r.UnmarshalBinary(nil))
+	assert.Error(t, r.UnmarshalBinary(state[:len(state)-1]))
+	assert.Error(t, r.UnmarshalBinary(append(state, 0)))
+	assert.Error(t, r.UnmarshalBinary(append([]byte("b3d\x02"), state[4:]...)))
+
+	bad := append([]byte(nil), state...)
+	bad[100] = 65 // block length
+	assert.Error(t, r.UnmarshalBinary(bad))
+}
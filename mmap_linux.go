@@ -0,0 +1,44 @@
+//go:build linux
+// +build linux
+
+package blake3
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// mmap maps size bytes of f starting at off. It returns the whole mapping,
+// which starts on a page boundary and must be passed to munmap, and the data
+// within it.
+func mmap(f *os.File, off, size int64) (mapping, data []byte, err error) {
+	// the mapping must start on a page boundary
+	start := off &^ int64(os.Getpagesize()-1)
+	if length := size + off - start; int64(int(length)) != length {
+		return nil, nil, errors.New("file too large to map")
+	}
+
+	conn, err := f.SyscallConn()
+	if err != nil {
+		return nil, nil, err
+	}
+	cerr := conn.Control(func(fd uintptr) {
+		mapping, err = unix.Mmap(int(fd), start, int(size+off-start), unix.PROT_READ, unix.MAP_SHARED)
+	})
+	if cerr != nil {
+		return nil, nil, cerr
+	} else if err != nil {
+		return nil, nil, err
+	}
+	_ = unix.Madvise(mapping, unix.MADV_SEQUENTIAL)
+
+	return mapping, mapping[off-start:], nil
+}
+
+// munmap releases a mapping returned by mmap. It must be the whole mapping,
+// not the data within it.
+func munmap(mapping []byte) error {
+	return unix.Munmap(mapping)
+}
@@ -0,0 +1,17 @@
+//go:build !linux
+// +build !linux
+
+package blake3
+
+import (
+	"errors"
+	"os"
+)
+
+func mmap(f *os.File, off, size int64) (mapping, data []byte, err error) {
+	return nil, nil, errors.New("mmap not supported")
+}
+
+func munmap(mapping []byte) error {
+	return nil
+}
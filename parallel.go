@@ -0,0 +1,293 @@
+package blake3
+
+import (
+	"errors"
+	"io"
+	"math/bits"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/zeebo/blake3/internal/alg"
+	"github.com/zeebo/blake3/internal/consts"
+)
+
+const (
+	// parallelMin is the smallest input worth splitting across goroutines.
+	parallelMin = 128 * 1024
+
+	// parallelGrain is the smallest subtree, in chunks, handed to a goroutine.
+	parallelGrain = 64
+
+	// parallelReadSize is the size of the reads issued by each goroutine when
+	// hashing an io.ReaderAt.
+	parallelReadSize = 256 * 1024
+)
+
+//
+// hashing large inputs across multiple goroutines
+//
+
+func (a *hasher) updateParallel(buf []byte, n int) {
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+
+	if len(buf) > 0 {
+		a.settle()
+	}
+
+	// top up the pending group so that the rest of buf starts on a boundary
+	if a.len > 0 && a.len < 8192 {
+		m := 8192 - int(a.len)
+		if m > len(buf) {
+			m = len(buf)
+		}
+		a.update(buf[:m])
+		buf = buf[m:]
+	}
+
+	// the last group must stay buffered for finalize, so keep at least a byte
+	bulk := 0
+	if len(buf) > 0 {
+		bulk = (len(buf) - 1) / 8192 * 8192
+	}
+	if n < 2 || bulk < parallelMin {
+		a.update(buf)
+		return
+	}
+
+	if a.len == 8192 {
+		a.consume(&a.buf)
+		a.len = 0
+		a.chunks += 8
+	}
+
+	subs := splitSubtrees(a.chunks, uint64(bulk)/consts.ChunkLen, n)
+	cvs := make([][8]uint32, len(subs))
+	flags, key := a.flags, a.key
+
+	_ = forEach(n, len(subs), func(w, j int) error {
+		s := subtreeHasher{flags: flags, key: key, ctr: subs[j].ctr}
+		s.update(buf[subs[j].off*consts.ChunkLen : (subs[j].off+subs[j].size)*consts.ChunkLen])
+		s.finalize(&cvs[j])
+		return nil
+	})
+
+	a.pushSubtrees(subs, cvs)
+	a.update(buf[bulk:])
+}
+
+func (a *hasher) updateReaderAt(r io.ReaderAt, size int64, n int) error {
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	if size < 0 {
+		return errors.New("negative size")
+	}
+
+	if size > 0 {
+		a.settle()
+	}
+
+	// fill the pending group directly from the reader
+	off := int64(0)
+	if a.len > 0 && a.len < 8192 {
+		m := 8192 - int64(a.len)
+		if m > size {
+			m = size
+		}
+		if err := readAtFull(r, a.buf[a.len:a.len+uint64(m)], off); err != nil {
+			return err
+		}
+		a.len += uint64(m)
+		off += m
+	}
+	if off == size {
+		return nil
+	}
+
+	if a.len == 8192 {
+		a.consume(&a.buf)
+		a.len = 0
+		a.chunks += 8
+	}
+
+	// the last group must stay buffered for finalize, so keep at least a byte
+	if bulk := (size - off - 1) / 8192 * 8192; bulk > 0 {
+		subs := splitSubtrees(a.chunks, uint64(bulk)/consts.ChunkLen, n)
+		cvs := make([][8]uint32, len(subs))
+		bufs := make([][]byte, n)
+		flags, key := a.flags, a.key
+
+		err := forEach(n, len(subs), func(w, j int) error {
+			if bufs[w] == nil {
+				bufs[w] = make([]byte, parallelReadSize)
+			}
+
+			s := subtreeHasher{flags: flags, key: key, ctr: subs[j].ctr}
+			pos := off + int64(subs[j].off*consts.ChunkLen)
+			for rem := int64(subs[j].size * consts.ChunkLen); rem > 0; {
+				buf := bufs[w]
+				if int64(len(buf)) > rem {
+					buf = buf[:rem]
+				}
+				if err := readAtFull(r, buf, pos); err != nil {
+					return err
+				}
+				s.update(buf)
+				pos += int64(len(buf))
+				rem -= int64(len(buf))
+			}
+			s.finalize(&cvs[j])
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		a.pushSubtrees(subs, cvs)
+		off += bulk
+	}
+
+	if err := readAtFull(r, a.buf[:size-off], off); err != nil {
+		return err
+	}
+	a.len = uint64(size - off)
+
+	return nil
+}
+
+func readAtFull(r io.ReaderAt, p []byte, off int64) error {
+	n, err := r.ReadAt(p, off)
+	if n == len(p) {
+		return nil
+	} else if err == nil || err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// forEach calls fn(w, j) for every j in [0, count) from n goroutines, where w
+// identifies the calling goroutine. It stops early and returns the first
+// error returned by fn.
+func forEach(n, count int, fn func(w, j int) error) error {
+	if n > count {
+		n = count
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		err  error
+		next = int64(-1)
+	)
+
+	wg.Add(n)
+	for w := 0; w < n; w++ {
+		go func(w int) {
+			defer wg.Done()
+			for {
+				j := int(atomic.AddInt64(&next, 1))
+				if j >= count {
+					return
+				}
+				if ferr := fn(w, j); ferr != nil {
+					mu.Lock()
+					if err == nil {
+						err = ferr
+					}
+					mu.Unlock()
+					atomic.StoreInt64(&next, int64(count))
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	return err
+}
+
+//
+// complete aligned subtrees
+//
+
+// subtree describes a complete, aligned subtree of chunks within an input.
+type subtree struct {
+	off  uint64 // offset of the first chunk in the input
+	ctr  uint64 // counter of the first chunk in the tree
+	size uint64 // number of chunks, always a power of two
+}
+
+// splitSubtrees splits the given number of chunks starting at the chunk
+// counter ctr into aligned subtrees, aiming for a few per goroutine so that
+// uneven scheduling evens out. Both ctr and chunks must be multiples of 8.
+func splitSubtrees(ctr, chunks uint64, n int) (subs []subtree) {
+	grain := uint64(parallelGrain)
+	for grain*8*uint64(n) <= chunks {
+		grain *= 2
+	}
+
+	for off := uint64(0); off < chunks; {
+		size := grain
+		for ctr&(size-1) != 0 || off+size > chunks {
+			size /= 2
+		}
+		subs = append(subs, subtree{off: off, ctr: ctr, size: size})
+		off, ctr = off+size, ctr+size
+	}
+
+	return subs
+}
+
+// pushSubtrees pushes the chaining values of consecutive subtrees that begin
+// at the current chunk counter onto the stack.
+func (a *hasher) pushSubtrees(subs []subtree, cvs [][8]uint32) {
+	// pending pairs must be merged before pushing a higher level so that the
+	// stack keeps the chains in order.
+	var cv chainVector
+	lvl := uint8(0)
+	for j, s := range subs {
+		if l := uint8(bits.TrailingZeros64(s.size)); l != lvl {
+			for a.stack.bufn > 0 {
+				a.stack.flush(a.flags, &a.key)
+			}
+			lvl = l
+		}
+		writeChain(&cvs[j], &cv, 0)
+		a.stack.pushN(lvl, &cv, 1, a.flags, &a.key)
+		a.chunks += s.size
+	}
+	for a.stack.bufn > 0 {
+		a.stack.flush(a.flags, &a.key)
+	}
+}
+
+// subtreeHasher computes the chaining value of a complete, aligned subtree
+// from whole groups of chunks.
+type subtreeHasher struct {
+	flags uint32
+	key   [8]uint32
+	ctr   uint64
+	stack cvstack
+}
+
+func (s *subtreeHasher) update(input []byte) {
+	var cv chainVector
+	var chain [8]uint32
+
+	for ; len(input) > 0; input = input[8192:] {
+		alg.HashF((*[8192]byte)(input), 8192, s.ctr, s.flags, &s.key, &cv, &chain)
+		s.stack.pushN(0, &cv, 8, s.flags, &s.key)
+		s.ctr += 8
+	}
+}
+
+func (s *subtreeHasher) finalize(out *[8]uint32) {
+	for s.stack.bufn > 0 {
+		s.stack.flush(s.flags, &s.key)
+	}
+	*out = s.stack.stack[bits.TrailingZeros64(s.stack.occ)%64]
+}
@@ -0,0 +1,188 @@
+package blake3
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"testing"
+
+	"github.com/zeebo/assert"
+	"github.com/zeebo/blake3/internal/consts"
+	"github.com/zeebo/blake3/internal/utils"
+)
+
+func TestWriteParallel(t *testing.T) {
+	x := make([]byte, 3<<20+100)
+	for i := range x {
+		x[i] = byte(i) % 251
+	}
+
+	for _, size := range []int{0, 1, 8192, parallelMin, parallelMin + 8193, 1 << 20, 3<<20 + 100} {
+		for _, prefix := range []int{0, 1, 1024, 8191, 8192, 8193, 3 * 8192} {
+			for _, n := range []int{0, 1, 2, 3, 8} {
+				if prefix > size {
+					continue
+				}
+
+				h1, h2 := New(), New()
+				_, _ = h1.Write(x[:size])
+				_, _ = h2.Write(x[:prefix])
+				_, _ = h2.WriteParallel(x[prefix:size], n)
+
+				assert.Equal(t, hex.EncodeToString(h1.Sum(nil)), hex.EncodeToString(h2.Sum(nil)))
+
+				// the hasher must keep working after a parallel write
+				_, _ = h1.Write(x[:10000])
+				_, _ = h2.Write(x[:10000])
+				assert.Equal(t, hex.EncodeToString(h1.Sum(nil)), hex.EncodeToString(h2.Sum(nil)))
+			}
+		}
+	}
+}
+
+func TestWriteParallel_AfterSum(t *testing.T) {
+	x := make([]byte, 1<<20+100)
+	for i := range x {
+		x[i] = byte(i) % 251
+	}
+	exp := Sum256(x)
+
+	// finalizing must leave the buffered chunks aligned for parallel writes
+	for _, prefix := range []int{1, 1024, 1025, 5000, 8192, 8193, 3*8192 + 1024, 100000} {
+		h := New()
+		_, _ = h.Write(x[:prefix])
+		_ = h.Sum(nil)
+		_ = h.Digest()
+		_, _ = h.WriteParallel(x[prefix:], 4)
+		assert.Equal(t, hex.EncodeToString(h.Sum(nil)), hex.EncodeToString(exp[:]))
+	}
+}
+
+func TestWriteParallel_Keyed(t *testing.T) {
+	x := make([]byte, 1<<20+1)
+	for i := range x {
+		x[i] = byte(i) % 251
+	}
+
+	h1 := hasher{flags: consts.Flag_Keyed}
+	utils.KeyFromBytes([]byte(testVectorKey), &h1.key)
+	h2 := h1
+
+	h1.update(x)
+	h2.updateParallel(x, 4)
+
+	var exp, got [64]byte
+	h1.finalize(exp[:])
+	h2.finalize(got[:])
+	assert.Equal(t, hex.EncodeToString(got[:]), hex.EncodeToString(exp[:]))
+}
+
+func TestSumParallel(t *testing.T) {
+	x := make([]byte, 1<<20+512)
+	for i := range x {
+		x[i] = byte(i) % 251
+	}
+
+	for _, size := range []int{0, 1024, 1025, parallelMin + 1, 1<<20 + 512} {
+		exp := Sum256(x[:size])
+		got := SumParallel(x[:size], 0)
+		assert.Equal(t, hex.EncodeToString(got[:]), hex.EncodeToString(exp[:]))
+	}
+}
+
+func TestWriteReaderAt(t *testing.T) {
+	x := make([]byte, 3<<20+100)
+	for i := range x {
+		x[i] = byte(i) % 251
+	}
+	r := bytes.NewReader(x)
+
+	for _, size := range []int{0, 1, 8192, 8193, parallelMin + 8193, parallelReadSize*3 + 1, 3<<20 + 100} {
+		for _, prefix := range []int{0, 1, 8191, 8192, 8193} {
+			for _, n := range []int{0, 1, 3} {
+				h1, h2 := New(), New()
+				_, _ = h1.Write(x[:prefix])
+				_, _ = h1.Write(x[:size])
+				_, _ = h2.Write(x[:prefix])
+
+				m, err := h2.WriteReaderAt(r, int64(size), n)
+				assert.NoError(t, err)
+				assert.Equal(t, m, size)
+
+				assert.Equal(t, hex.EncodeToString(h1.Sum(nil)), hex.EncodeToString(h2.Sum(nil)))
+			}
+		}
+	}
+
+	exp := Sum256(x)
+	got, err := HashReaderAt(r, int64(len(x)), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(got[:]), hex.EncodeToString(exp[:]))
+}
+
+func TestWriteReaderAt_AfterSum(t *testing.T) {
+	x := make([]byte, 1<<20+100)
+	for i := range x {
+		x[i] = byte(i) % 251
+	}
+	exp := Sum256(x)
+
+	for _, prefix := range []int{1, 1025, 8192, 8193, 3*8192 + 1024} {
+		h := New()
+		_, _ = h.Write(x[:prefix])
+		_ = h.Sum(nil)
+		_, err := h.WriteReaderAt(bytes.NewReader(x[prefix:]), int64(len(x)-prefix), 4)
+		assert.NoError(t, err)
+		assert.Equal(t, hex.EncodeToString(h.Sum(nil)), hex.EncodeToString(exp[:]))
+	}
+}
+
+type errReaderAt struct{ off int64 }
+
+func (e errReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off+int64(len(p)) > e.off {
+		return 0, errors.New("read failed")
+	}
+	return len(p), nil
+}
+
+func TestWriteReaderAt_Errors(t *testing.T) {
+	x := make([]byte, 1<<20)
+
+	// short reads are reported for every region of the input
+	for _, size := range []int64{10, 8192, 8193, 1 << 20} {
+		_, err := HashReaderAt(bytes.NewReader(x[:size]), size+1, 4)
+		assert.Equal(t, err, io.ErrUnexpectedEOF)
+	}
+
+	for _, off := range []int64{0, 8192, 1 << 19, 1<<20 - 1} {
+		_, err := HashReaderAt(errReaderAt{off: off}, 1<<20, 4)
+		assert.Error(t, err)
+	}
+
+	_, err := HashReaderAt(bytes.NewReader(x), -1, 0)
+	assert.Error(t, err)
+}
+
+func BenchmarkSumParallel(b *testing.B) {
+	run := func(b *testing.B, size int64) {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			buf := make([]byte, size)
+			b.SetBytes(size)
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			var sum [32]byte
+			for i := 0; i < b.N; i++ {
+				sum = SumParallel(buf, 0)
+			}
+			runtime.KeepAlive(sum)
+		})
+	}
+
+	run(b, 1024*1024)
+	run(b, 16*1024*1024)
+}
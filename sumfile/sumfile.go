@@ -0,0 +1,257 @@
+// Package sumfile reads and writes checksum files like the ones printed by
+// b3sum, so that files can be verified without running the command.
+//
+// Two line formats are supported: the GNU coreutils format, "HASH  NAME", and
+// the BSD tag format, "BLAKE3 (NAME) = HASH". File names that contain a
+// backslash or a line break are escaped and the line is prefixed with a
+// backslash, which is also how GNU coreutils escapes them.
+package sumfile
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/zeebo/blake3"
+)
+
+// Entry is a single line of a checksum file.
+type Entry struct {
+	// Sum is the expected output of the hash, usually 32 bytes.
+	Sum []byte
+
+	// Name is the name of the file, unescaped.
+	Name string
+
+	// Tag is true if the line is in the BSD tag format.
+	Tag bool
+}
+
+// Verify reports whether the output read from d matches the sum of the
+// entry. The comparison is constant time. An entry without a sum never
+// matches.
+func (e Entry) Verify(d *blake3.Digest) bool {
+	if len(e.Sum) == 0 {
+		return false
+	}
+	got := make([]byte, len(e.Sum))
+	_, _ = d.Read(got)
+	return subtle.ConstantTimeCompare(got, e.Sum) == 1
+}
+
+// String returns the line for the entry, without a line ending.
+func (e Entry) String() string {
+	return string(AppendLine(nil, e))
+}
+
+// AppendLine appends the line for the entry, without a line ending, to b.
+// Invalid UTF-8 in the name is replaced, like b3sum does.
+func AppendLine(b []byte, e Entry) []byte {
+	name, escaped := EscapeName(e.Name)
+	if escaped {
+		b = append(b, '\\')
+	}
+	if e.Tag {
+		b = append(b, "BLAKE3 ("...)
+		b = append(b, name...)
+		b = append(b, ") = "...)
+		return appendHex(b, e.Sum)
+	}
+	b = appendHex(b, e.Sum)
+	b = append(b, "  "...)
+	return append(b, name...)
+}
+
+func appendHex(b, sum []byte) []byte {
+	n := len(b)
+	b = append(b, make([]byte, hex.EncodedLen(len(sum)))...)
+	hex.Encode(b[n:], sum)
+	return b
+}
+
+// EscapeName returns name as it is written in a checksum file and whether it
+// had to be escaped, in which case the line must be prefixed with a
+// backslash.
+func EscapeName(name string) (string, bool) {
+	if !utf8.ValidString(name) {
+		var b strings.Builder
+		for _, r := range name {
+			_, _ = b.WriteRune(r)
+		}
+		name = b.String()
+	}
+
+	if !strings.ContainsAny(name, "\\\n\r") {
+		return name, false
+	}
+	return escaper.Replace(name), true
+}
+
+var escaper = strings.NewReplacer("\\", "\\\\", "\n", "\\n", "\r", "\\r")
+
+// SumSize is the size of the sums accepted by ParseLine, the default output
+// size of b3sum.
+const SumSize = 32
+
+// ParseLine parses a single line of a checksum file. Any line ending is
+// ignored. The sum must be exactly SumSize bytes, so that a shortened sum
+// can not pass for a match when only its prefix is compared.
+func ParseLine(line string) (e Entry, err error) {
+	return parseLine(line, SumSize)
+}
+
+func parseLine(line string, size int) (e Entry, err error) {
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return e, errors.New("empty line")
+	}
+
+	escaped := line[0] == '\\'
+	if escaped {
+		line = line[1:]
+	}
+
+	var sum, name string
+	if rest, ok := strings.CutPrefix(line, "BLAKE3 ("); ok {
+		i := strings.LastIndex(rest, ") = ")
+		if i < 0 {
+			return e, errors.New("invalid tag line")
+		}
+		name, sum = rest[:i], rest[i+len(") = "):]
+		e.Tag = true
+	} else {
+		i := strings.IndexByte(line, ' ')
+		if i < 0 || !(strings.HasPrefix(line[i:], "  ") || strings.HasPrefix(line[i:], " *")) {
+			return e, errors.New("invalid separator")
+		}
+		sum, name = line[:i], line[i+2:]
+	}
+
+	if sum == "" {
+		return e, errors.New("missing hash")
+	}
+	if e.Sum, err = hex.DecodeString(sum); err != nil {
+		return e, errors.New("invalid hash")
+	}
+	if len(e.Sum) != size {
+		return e, errors.New("invalid hash length")
+	}
+
+	if escaped {
+		if name, err = unescape(name); err != nil {
+			return e, err
+		}
+	}
+	if name == "" {
+		return e, errors.New("missing file name")
+	}
+	if strings.IndexByte(name, 0) >= 0 {
+		return e, errors.New("null character in file name")
+	}
+	e.Name = name
+
+	return e, nil
+}
+
+func unescape(name string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] != '\\' {
+			_ = b.WriteByte(name[i])
+			continue
+		}
+
+		i++
+		if i == len(name) {
+			return "", errors.New("invalid backslash escape")
+		}
+		switch name[i] {
+		case '\\':
+			_ = b.WriteByte('\\')
+		case 'n':
+			_ = b.WriteByte('\n')
+		case 'r':
+			_ = b.WriteByte('\r')
+		default:
+			return "", errors.New("invalid backslash escape")
+		}
+	}
+	return b.String(), nil
+}
+
+// A SyntaxError is returned by Reader.Next for a malformed line. Reading can
+// continue with the next line.
+type SyntaxError struct {
+	Line int // line number, starting at 1
+	Err  error
+}
+
+func (e *SyntaxError) Error() string { return fmt.Sprintf("line %d: %v", e.Line, e.Err) }
+
+func (e *SyntaxError) Unwrap() error { return e.Err }
+
+// Reader reads the entries of a checksum file.
+type Reader struct {
+	// SumSize is the size in bytes that every sum must have, as written by
+	// b3sum --length. Lines with a sum of any other size are syntax errors.
+	// Zero means the package level SumSize.
+	SumSize int
+
+	br   *bufio.Reader
+	line int
+}
+
+// NewReader returns a Reader that reads entries from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{br: bufio.NewReader(r)}
+}
+
+// Next returns the next entry. It returns io.EOF when there are no more
+// entries, and a *SyntaxError for a malformed line.
+func (r *Reader) Next() (Entry, error) {
+	line, err := r.br.ReadString('\n')
+	if line == "" {
+		if err == nil {
+			err = io.EOF
+		}
+		return Entry{}, err
+	}
+	if err != nil && err != io.EOF {
+		return Entry{}, err
+	}
+
+	size := r.SumSize
+	if size == 0 {
+		size = SumSize
+	}
+
+	r.line++
+	e, err := parseLine(line, size)
+	if err != nil {
+		return Entry{}, &SyntaxError{Line: r.line, Err: err}
+	}
+	return e, nil
+}
+
+// Writer writes the entries of a checksum file.
+type Writer struct {
+	w   io.Writer
+	buf []byte
+}
+
+// NewWriter returns a Writer that writes entries to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Write writes the line for the entry, followed by a newline.
+func (w *Writer) Write(e Entry) error {
+	w.buf = append(AppendLine(w.buf[:0], e), '\n')
+	_, err := w.w.Write(w.buf)
+	return err
+}
@@ -0,0 +1,182 @@
+package sumfile
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/zeebo/assert"
+
+	"github.com/zeebo/blake3"
+)
+
+const someData = "b224a1da2bf5e72b337dc6dde457a05265a06dec8875be379e2ad2be5edb3bf2"
+
+func TestParseLine(t *testing.T) {
+	for _, test := range []struct {
+		line string
+		name string
+		tag  bool
+	}{
+		{someData + "  foo", "foo", false},
+		{someData + " *foo", "foo", false},
+		{someData + "  foo bar\r\n", "foo bar", false},
+		{someData + "  a  b", "a  b", false},
+		{"BLAKE3 (foo) = " + someData, "foo", true},
+		{"BLAKE3 (a) = b) = " + someData, "a) = b", true},
+		{"\\" + someData + "  a\\\\b\\nc\\rd", "a\\b\nc\rd", false},
+		{"\\BLAKE3 (a\\nb) = " + someData + "\n", "a\nb", true},
+	} {
+		e, err := ParseLine(test.line)
+		assert.NoError(t, err)
+		assert.Equal(t, e.Name, test.name)
+		assert.Equal(t, e.Tag, test.tag)
+		assert.Equal(t, string(appendHex(nil, e.Sum)), someData)
+	}
+
+	for _, test := range []struct {
+		line string
+		err  string
+	}{
+		{"", "empty line"},
+		{"\n", "empty line"},
+		{someData + " foo", "invalid separator"},
+		{someData, "invalid separator"},
+		{"  foo", "missing hash"},
+		{"xyz  foo", "invalid hash"},
+		{"b2  foo", "invalid hash length"},
+		{someData[:62] + "  foo", "invalid hash length"},
+		{someData + "00  foo", "invalid hash length"},
+		{"BLAKE3 (foo) = b2", "invalid hash length"},
+		{someData + "  ", "missing file name"},
+		{"BLAKE3 (foo) " + someData, "invalid tag line"},
+		{"BLAKE3 () = " + someData, "missing file name"},
+		{"\\" + someData + "  a\\tb", "invalid backslash escape"},
+		{"\\" + someData + "  a\\", "invalid backslash escape"},
+		{someData + "  a\x00b", "null character in file name"},
+	} {
+		_, err := ParseLine(test.line)
+		assert.Error(t, err)
+		assert.Equal(t, err.Error(), test.err)
+	}
+}
+
+func TestAppendLine(t *testing.T) {
+	sum := blake3.Sum256([]byte("some data"))
+
+	for _, name := range []string{"foo", "foo bar", "a\\b", "a\nb\rc", "BLAKE3 (x) = y", ") = "} {
+		for _, tag := range []bool{false, true} {
+			e := Entry{Sum: sum[:], Name: name, Tag: tag}
+			got, err := ParseLine(e.String())
+			assert.NoError(t, err)
+			assert.DeepEqual(t, got, e)
+		}
+	}
+
+	assert.Equal(t, Entry{Sum: sum[:], Name: "foo"}.String(), someData+"  foo")
+	assert.Equal(t, Entry{Sum: sum[:], Name: "foo", Tag: true}.String(), "BLAKE3 (foo) = "+someData)
+	assert.Equal(t, Entry{Sum: sum[:], Name: "a\nb"}.String(), "\\"+someData+"  a\\nb")
+}
+
+func TestEscapeName(t *testing.T) {
+	name, escaped := EscapeName("x\ny\rz\\")
+	assert.Equal(t, name, "x\\ny\\rz\\\\")
+	assert.That(t, escaped)
+
+	name, escaped = EscapeName("a\xffb")
+	assert.Equal(t, name, "a�b")
+	assert.That(t, !escaped)
+}
+
+func TestReader(t *testing.T) {
+	input := strings.Join([]string{
+		someData + "  foo",
+		"garbage",
+		"BLAKE3 (bar) = " + someData,
+		"",
+		someData + "  baz",
+	}, "\n")
+
+	r := NewReader(strings.NewReader(input))
+	var names []string
+	var lines []int
+	for {
+		e, err := r.Next()
+		var se *SyntaxError
+		if errors.Is(err, io.EOF) {
+			break
+		} else if errors.As(err, &se) {
+			lines = append(lines, se.Line)
+			continue
+		}
+		assert.NoError(t, err)
+		names = append(names, e.Name)
+	}
+
+	assert.DeepEqual(t, names, []string{"foo", "bar", "baz"})
+	assert.DeepEqual(t, lines, []int{2, 4})
+}
+
+func TestReader_SumSize(t *testing.T) {
+	input := someData[:32] + "  short\n" + someData + "  full\n"
+
+	// by default only full size sums are accepted
+	r := NewReader(strings.NewReader(input))
+	_, err := r.Next()
+	var se *SyntaxError
+	assert.That(t, errors.As(err, &se))
+	e, err := r.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, e.Name, "full")
+
+	r = NewReader(strings.NewReader(input))
+	r.SumSize = 16
+	e, err = r.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, e.Name, "short")
+	assert.Equal(t, len(e.Sum), 16)
+	_, err = r.Next()
+	assert.That(t, errors.As(err, &se))
+}
+
+func TestWriter(t *testing.T) {
+	sum := blake3.Sum256([]byte("some data"))
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	assert.NoError(t, w.Write(Entry{Sum: sum[:], Name: "foo"}))
+	assert.NoError(t, w.Write(Entry{Sum: sum[:], Name: "bar", Tag: true}))
+	assert.Equal(t, buf.String(), someData+"  foo\nBLAKE3 (bar) = "+someData+"\n")
+
+	r := NewReader(&buf)
+	e, err := r.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, e.Name, "foo")
+	e, err = r.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, e.Name, "bar")
+	_, err = r.Next()
+	assert.That(t, errors.Is(err, io.EOF))
+}
+
+func TestEntry_Verify(t *testing.T) {
+	e, err := ParseLine(someData + "  foo")
+	assert.NoError(t, err)
+
+	h := blake3.New()
+	_, _ = h.WriteString("some data")
+	assert.That(t, e.Verify(h.Digest()))
+
+	_, _ = h.WriteString("!")
+	assert.That(t, !e.Verify(h.Digest()))
+
+	e.Sum = e.Sum[:16]
+	h.Reset()
+	_, _ = h.WriteString("some data")
+	assert.That(t, e.Verify(h.Digest()))
+
+	e.Sum = nil
+	assert.That(t, !e.Verify(h.Digest()))
+}